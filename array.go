@@ -0,0 +1,215 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// arrayTarget returns a scanner for a pointer to a slice, or to a pointer to a slice, that
+// has no conversion of its own, such as *[]int64 or **[]string. The column is read as a
+// Postgres array, from its text form, e.g. {1,2,NULL} or {{1,2},{3,4}}, or from a slice
+// already decoded by the driver. Elements are converted like columns are, so NULL elements
+// need pointer or sql.Null* elements. A NULL column stores a nil slice or pointer, an empty
+// array an empty slice.
+func (c *Config) arrayTarget(target any) sql.Scanner {
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return nil
+	}
+	sliceType := val.Type().Elem()
+	if sliceType.Kind() == reflect.Ptr {
+		sliceType = sliceType.Elem()
+	}
+	if !isArrayType(sliceType) {
+		return nil
+	}
+	return scanFunc(func(src any) error {
+		return c.scanArray(val.Elem(), src)
+	})
+}
+
+// isArrayType reports whether t is a slice scanned as a Postgres array: one without a
+// conversion of its own, unlike []byte, and with convertible elements.
+func isArrayType(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+	if _, err := converter(reflect.PointerTo(reflect.PointerTo(t))); err == nil {
+		return false
+	}
+	elemType := t.Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if isArrayType(elemType) {
+		return true
+	}
+	_, err := converter(reflect.PointerTo(reflect.PointerTo(elemType)))
+	return err == nil
+}
+
+// scanArray stores the array value src into dst.
+func (c *Config) scanArray(dst reflect.Value, src any) error {
+	if src == nil {
+		dst.SetZero()
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr {
+		ptr := reflect.New(dst.Type().Elem())
+		if err := c.scanArray(ptr.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	}
+
+	var items []any
+	if s, ok := text(src); ok {
+		var err error
+		if items, err = parseArray(s); err != nil {
+			return err
+		}
+	} else if v := reflect.ValueOf(src); v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		items = make([]any, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).Interface()
+		}
+	} else {
+		return fmt.Errorf("cannot scan %T value into array %s", src, dst.Type())
+	}
+
+	elemType := dst.Type().Elem()
+	nullable := elemType.Kind() == reflect.Ptr || isNullWrapper(elemType) || elemType.Implements(optionalType)
+	slice := reflect.MakeSlice(dst.Type(), len(items), len(items))
+	for i, item := range items {
+		if item == nil && !nullable {
+			return fmt.Errorf("NULL array element %d cannot be stored in %s", i+1, elemType)
+		}
+		if err := c.scan(slice.Index(i).Addr().Interface(), item); err != nil {
+			return fmt.Errorf("array element %d: %w", i+1, err)
+		}
+	}
+	dst.Set(slice)
+	return nil
+}
+
+// parseArray splits the text form of a Postgres array into its elements: nil for NULL,
+// the unescaped text for a value and a []any for a nested array.
+func parseArray(s string) ([]any, error) {
+	p := &arrayParser{s: s}
+	if strings.HasPrefix(s, "[") {
+		// explicit bounds, e.g. [0:1]={1,2}
+		if i := strings.IndexByte(s, '='); i >= 0 {
+			p.pos = i + 1
+		}
+	}
+	items, err := p.parse()
+	if err == nil && p.pos != len(s) {
+		err = fmt.Errorf("unexpected %q after array", s[p.pos:])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("malformed array value %q: %w", s, err)
+	}
+	return items, nil
+}
+
+// arrayParser reads the text form of a Postgres array.
+type arrayParser struct {
+	s   string
+	pos int
+}
+
+// parse reads an array starting at the current position.
+func (p *arrayParser) parse() ([]any, error) {
+	if p.peek() != '{' {
+		return nil, fmt.Errorf("expected '{' at offset %d", p.pos)
+	}
+	p.pos++
+	items := []any{}
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		return items, nil
+	}
+	for {
+		p.skipSpace()
+		item, err := p.element()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return items, nil
+		default:
+			return nil, fmt.Errorf("expected ',' or '}' at offset %d", p.pos)
+		}
+	}
+}
+
+// element reads a single element: a nested array, a quoted or an unquoted value.
+func (p *arrayParser) element() (any, error) {
+	switch p.peek() {
+	case '{':
+		return p.parse()
+	case '"':
+		p.pos++
+		var b strings.Builder
+		for p.pos < len(p.s) {
+			ch := p.s[p.pos]
+			p.pos++
+			switch {
+			case ch == '\\' && p.pos < len(p.s):
+				b.WriteByte(p.s[p.pos])
+				p.pos++
+			case ch == '"':
+				return b.String(), nil
+			default:
+				b.WriteByte(ch)
+			}
+		}
+		return nil, fmt.Errorf("unterminated quote")
+	}
+
+	var b strings.Builder
+	escaped := false
+	for p.pos < len(p.s) && p.s[p.pos] != ',' && p.s[p.pos] != '}' {
+		if p.s[p.pos] == '\\' && p.pos+1 < len(p.s) {
+			p.pos++
+			escaped = true
+		}
+		b.WriteByte(p.s[p.pos])
+		p.pos++
+	}
+	item := strings.TrimRight(b.String(), " \t\n")
+	if item == "" {
+		return nil, fmt.Errorf("empty element at offset %d", p.pos)
+	}
+	if !escaped && strings.EqualFold(item, "NULL") {
+		return nil, nil
+	}
+	return item, nil
+}
+
+// peek returns the byte at the current position, 0 at the end.
+func (p *arrayParser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+// skipSpace moves past whitespace.
+func (p *arrayParser) skipSpace() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
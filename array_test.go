@@ -0,0 +1,80 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArray(t *testing.T) {
+	var ids []int64
+	require.NoError(t, sqlnull.Target(&ids).(sql.Scanner).Scan("{1,2,3}"))
+	require.Equal(t, []int64{1, 2, 3}, ids)
+
+	require.NoError(t, sqlnull.Target(&ids).(sql.Scanner).Scan("{}"))
+	require.Equal(t, []int64{}, ids)
+
+	require.NoError(t, sqlnull.Target(&ids).(sql.Scanner).Scan(nil))
+	require.Nil(t, ids)
+
+	require.ErrorContains(t, sqlnull.Target(&ids).(sql.Scanner).Scan("{1,NULL}"), "NULL array element 2 cannot be stored in int64")
+	require.ErrorContains(t, sqlnull.Target(&ids).(sql.Scanner).Scan("{1,x}"), "array element 2: ")
+	require.ErrorContains(t, sqlnull.Target(&ids).(sql.Scanner).Scan("{1,2"), `malformed array value "{1,2"`)
+
+	var names *[]*string
+	require.NoError(t, sqlnull.Target(&names).(sql.Scanner).Scan(`{"a,b",NULL,"say \"hi\"", plain ,"NULL"}`))
+	require.Len(t, *names, 5)
+	require.Equal(t, "a,b", *(*names)[0])
+	require.Nil(t, (*names)[1])
+	require.Equal(t, `say "hi"`, *(*names)[2])
+	require.Equal(t, "plain", *(*names)[3])
+	require.Equal(t, "NULL", *(*names)[4])
+
+	require.NoError(t, sqlnull.Target(&names).(sql.Scanner).Scan(nil))
+	require.Nil(t, names)
+
+	var scores []float64
+	require.NoError(t, sqlnull.Target(&scores).(sql.Scanner).Scan([]byte("[0:1]={1.5,2}")))
+	require.Equal(t, []float64{1.5, 2}, scores)
+
+	// already decoded by the driver
+	require.NoError(t, sqlnull.Target(&scores).(sql.Scanner).Scan([]any{1.5, int64(3)}))
+	require.Equal(t, []float64{1.5, 3}, scores)
+
+	var matrix [][]int64
+	require.NoError(t, sqlnull.Target(&matrix).(sql.Scanner).Scan("{{1,2},{3,4}}"))
+	require.Equal(t, [][]int64{{1, 2}, {3, 4}}, matrix)
+
+	var flags []sql.NullBool
+	require.NoError(t, sqlnull.Target(&flags).(sql.Scanner).Scan("{t,NULL,f}"))
+	require.Equal(t, []sql.NullBool{{Bool: true, Valid: true}, {}, {Bool: false, Valid: true}}, flags)
+
+	var blob []byte
+	require.Equal(t, &blob, sqlnull.Target(&blob))
+}
+
+func TestArrayStruct(t *testing.T) {
+	db := makeusers(t)
+
+	type Post struct {
+		ID   int64     `db:"id"`
+		Tags *[]string `db:"tags"`
+	}
+	rows, err := db.Query("SELECT id, CASE id WHEN 1 THEN '{go,sql}' END AS tags FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var posts []Post
+	for rows.Next() {
+		var post Post
+		require.NoError(t, sqlnull.ScanStruct(rows, &post))
+		posts = append(posts, post)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []string{"go", "sql"}, *posts[0].Tags)
+	require.Nil(t, posts[1].Tags)
+}
@@ -0,0 +1,46 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// ConvertAssign stores src into dst with the same rules ScanStruct applies to a column,
+// so message queue consumers, CSV parsers and other code outside database scanning can
+// share one conversion ruleset. dst is a pointer like a scan target: NULL leaves a pointer
+// to pointer nil and sets a plain value to its zero value. src may be any statement
+// argument: nil pointers, invalid sql.Null types and empty Optionals are NULL.
+//
+//	var age *int
+//	err := sqlnull.ConvertAssign(&age, record["age"])
+func ConvertAssign(dst, src any) error {
+	return Default().ConvertAssign(dst, src)
+}
+
+// ConvertAssign stores src into dst with the scan options of c.
+func (c *Config) ConvertAssign(dst, src any) error {
+	if dst == nil {
+		return fmt.Errorf("ConvertAssign destination must be a non-nil pointer, got nil")
+	}
+	if v, err := c.driverValue(src); err == nil {
+		if v, err := driver.DefaultParameterConverter.ConvertValue(v); err == nil {
+			src = v
+		}
+	}
+
+	switch t := c.fieldTarget(dst).(type) {
+	case sql.Scanner:
+		return t.Scan(src)
+	case *any:
+		src, _, err := c.limit(src)
+		if err != nil {
+			return err
+		}
+		*t = src
+		return nil
+	}
+	return fmt.Errorf("ConvertAssign destination %T is not supported", dst)
+}
@@ -0,0 +1,49 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAssign(t *testing.T) {
+	var n int
+	require.NoError(t, sqlnull.ConvertAssign(&n, "42"))
+	require.Equal(t, 42, n)
+	require.NoError(t, sqlnull.ConvertAssign(&n, nil))
+	require.Equal(t, 0, n)
+
+	var s *string
+	require.NoError(t, sqlnull.ConvertAssign(&s, sqlnull.Ptr("john")))
+	require.Equal(t, "john", *s)
+	require.NoError(t, sqlnull.ConvertAssign(&s, sql.NullString{}))
+	require.Nil(t, s)
+	require.NoError(t, sqlnull.ConvertAssign(&s, sqlnull.Some([]byte("jane"))))
+	require.Equal(t, "jane", *s)
+
+	var f float64
+	require.NoError(t, sqlnull.ConvertAssign(&f, int32(7)))
+	require.Equal(t, 7.0, f)
+
+	var ns sql.NullInt64
+	require.NoError(t, sqlnull.ConvertAssign(&ns, uint8(3)))
+	require.Equal(t, sql.NullInt64{Int64: 3, Valid: true}, ns)
+
+	var at *time.Time
+	now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.FixedZone("CEST", 7200))
+	require.NoError(t, sqlnull.NewConfig(sqlnull.WithUTC()).ConvertAssign(&at, now))
+	require.Equal(t, now.UTC(), *at)
+
+	var v any
+	require.NoError(t, sqlnull.ConvertAssign(&v, sqlnull.Ptr(int16(5))))
+	require.Equal(t, int64(5), v)
+
+	require.Error(t, sqlnull.ConvertAssign(&n, "abc"))
+	require.ErrorIs(t, sqlnull.NewConfig(sqlnull.WithVersion(2)).ConvertAssign(&n, nil), sqlnull.ErrNull)
+	require.Error(t, sqlnull.ConvertAssign(nil, 1))
+}
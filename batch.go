@@ -0,0 +1,207 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Batch holds a whole result set column by column, see ScanBatch.
+type Batch struct {
+	Len     int           // number of rows
+	Columns []BatchColumn // one per result column, in order
+}
+
+// BatchColumn holds the values of a single result column.
+//
+// Values is a typed slice picked from the first non-NULL value of the column: []int64,
+// []float64, []bool, []string, [][]byte or []time.Time. NULL rows hold the zero value
+// and have their bit cleared in Validity. A column whose values change type from row to
+// row, or that is entirely NULL, is held as []any with nil for NULL.
+type BatchColumn struct {
+	Name     string
+	Values   any
+	Validity []byte // bit i, in least significant bit order, is set when row i is not NULL
+}
+
+// Valid reports whether row i of the column is not NULL.
+func (c *BatchColumn) Valid(i int) bool {
+	return c.Validity[i/8]&(1<<(i%8)) != 0
+}
+
+// Column returns the column with the given name, or nil.
+func (b *Batch) Column(name string) *BatchColumn {
+	for i := range b.Columns {
+		if b.Columns[i].Name == name {
+			return &b.Columns[i]
+		}
+	}
+	return nil
+}
+
+// ScanBatch reads the remaining rows of rows column-wise into typed slices with a validity
+// bitmap per column, without reflection or per-row targets. The layout suits analytical
+// post-processing better than a slice of structs. rows is always closed on return.
+//
+//	batch, err := sqlnull.ScanBatch(rows)
+//	amount := batch.Column("amount")
+//	var total float64
+//	for i, v := range amount.Values.([]float64) {
+//		if amount.Valid(i) {
+//			total += v
+//		}
+//	}
+func ScanBatch(rows *sql.Rows) (*Batch, error) {
+	return Default().ScanBatch(rows)
+}
+
+// ScanBatch reads the remaining rows of rows column-wise, applying the byte limit and time options of c to each value.
+func (c *Config) ScanBatch(rows *sql.Rows) (*Batch, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	builders := make([]batchBuilder, len(columns))
+	values := make([]any, len(columns))
+	targets := make([]any, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+
+	n := 0
+	for rows.Next() {
+		if err := rows.Scan(targets...); err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			if v, _, err = c.limit(v); err != nil {
+				return nil, &ColumnError{Index: i, Column: columns[i], Err: err}
+			}
+			builders[i].add(n, c.finish(v))
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	batch := &Batch{Len: n, Columns: make([]BatchColumn, len(columns))}
+	for i, column := range columns {
+		batch.Columns[i] = builders[i].column(column, n)
+	}
+	return batch, rows.Close()
+}
+
+// batchBuilder collects the values of a column for ScanBatch.
+type batchBuilder struct {
+	values   any // nil until the first non-NULL value
+	validity []byte
+}
+
+// add appends v as row i.
+func (b *batchBuilder) add(i int, v any) {
+	if i%8 == 0 {
+		b.validity = append(b.validity, 0)
+	}
+	if v != nil {
+		b.validity[i/8] |= 1 << (i % 8)
+	}
+
+	if b.values == nil {
+		if v == nil {
+			return
+		}
+		// NULL rows read so far become zero values of the new slice
+		switch v.(type) {
+		case int64:
+			b.values = make([]int64, i)
+		case float64:
+			b.values = make([]float64, i)
+		case bool:
+			b.values = make([]bool, i)
+		case string:
+			b.values = make([]string, i)
+		case []byte:
+			b.values = make([][]byte, i)
+		case time.Time:
+			b.values = make([]time.Time, i)
+		default:
+			b.values = make([]any, i)
+		}
+	}
+
+	var ok bool
+	switch values := b.values.(type) {
+	case []int64:
+		b.values, ok = appendBatch(values, v)
+	case []float64:
+		b.values, ok = appendBatch(values, v)
+	case []bool:
+		b.values, ok = appendBatch(values, v)
+	case []string:
+		b.values, ok = appendBatch(values, v)
+	case [][]byte:
+		b.values, ok = appendBatch(values, v)
+	case []time.Time:
+		b.values, ok = appendBatch(values, v)
+	case []any:
+		b.values, ok = append(values, v), true
+	}
+	if !ok {
+		b.values = append(b.anyValues(i), v)
+	}
+}
+
+// appendBatch appends v, or the zero value for NULL, to values, reporting false when v is of another type.
+func appendBatch[T any](values []T, v any) (any, bool) {
+	var t T
+	if v != nil {
+		var ok bool
+		if t, ok = v.(T); !ok {
+			return values, false
+		}
+	}
+	return append(values, t), true
+}
+
+// anyValues returns the first n values collected so far as []any with nil for NULL.
+func (b *batchBuilder) anyValues(n int) []any {
+	result := make([]any, n)
+	for i := range result {
+		if b.validity[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		switch values := b.values.(type) {
+		case []int64:
+			result[i] = values[i]
+		case []float64:
+			result[i] = values[i]
+		case []bool:
+			result[i] = values[i]
+		case []string:
+			result[i] = values[i]
+		case [][]byte:
+			result[i] = values[i]
+		case []time.Time:
+			result[i] = values[i]
+		case []any:
+			result[i] = values[i]
+		}
+	}
+	return result
+}
+
+// column returns the collected values as a BatchColumn of n rows.
+func (b *batchBuilder) column(name string, n int) BatchColumn {
+	if b.values == nil {
+		b.values = make([]any, n)
+	}
+	if b.validity == nil {
+		b.validity = []byte{}
+	}
+	return BatchColumn{Name: name, Values: b.values, Validity: b.validity}
+}
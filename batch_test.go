@@ -0,0 +1,38 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanBatch(t *testing.T) {
+	db := makeusers(t)
+	_, err := db.Exec(`INSERT INTO users (id, first_name, last_name) VALUES (3, 'joe', 'x')`)
+	require.NoError(t, err)
+
+	rows, err := db.Query("SELECT id, first_name, CASE id WHEN 3 THEN 42 ELSE last_name END AS last_name, verified_at FROM users ORDER BY id")
+	require.NoError(t, err)
+
+	batch, err := sqlnull.ScanBatch(rows)
+	require.NoError(t, err)
+	require.Equal(t, 3, batch.Len)
+
+	id := batch.Column("id")
+	require.Equal(t, []int64{1, 2, 3}, id.Values)
+	require.Equal(t, []byte{0b111}, id.Validity)
+	require.Equal(t, []string{"john", "jane", "joe"}, batch.Column("first_name").Values)
+
+	lastName := batch.Column("last_name")
+	require.Equal(t, []any{"doe", nil, int64(42)}, lastName.Values)
+	require.True(t, lastName.Valid(0))
+	require.False(t, lastName.Valid(1))
+
+	verifiedAt := batch.Column("verified_at")
+	require.Equal(t, []any{nil, nil, nil}, verifiedAt.Values)
+	require.Equal(t, []byte{0}, verifiedAt.Validity)
+	require.Nil(t, batch.Column("missing"))
+}
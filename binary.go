@@ -0,0 +1,117 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var (
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	binaryMarshalerType   = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+	valuerType            = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	textUnmarshalerType   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// nullBinary scans []byte columns into a type implementing encoding.BinaryUnmarshaler.
+type nullBinary struct {
+	typ   reflect.Type
+	value any
+}
+
+// Scan implements the sql.Scanner interface for nullBinary.
+func (n *nullBinary) Scan(src any) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		n.value = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		// text is the text form for types that have one, such as netip.Addr
+		if reflect.PointerTo(n.typ).Implements(textUnmarshalerType) {
+			return n.unmarshalText(src)
+		}
+		b = []byte(v)
+	default:
+		return fmt.Errorf("cannot unmarshal %T value into %s", src, n.typ)
+	}
+
+	ptr := reflect.New(n.typ)
+	if err := ptr.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(b); err != nil {
+		// drivers such as MySQL's return TEXT columns as []byte too
+		if reflect.PointerTo(n.typ).Implements(textUnmarshalerType) && n.unmarshalText(src) == nil {
+			return nil
+		}
+		return err
+	}
+	n.value = ptr.Elem().Interface()
+	return nil
+}
+
+// unmarshalText stores src into n through the encoding.TextUnmarshaler of its type.
+func (n *nullBinary) unmarshalText(src any) error {
+	text := &nullText{typ: n.typ}
+	if err := text.Scan(src); err != nil {
+		return err
+	}
+	n.value = text.value
+	return nil
+}
+
+// Value implements the driver.Valuer interface for nullBinary.
+func (n *nullBinary) Value() (driver.Value, error) {
+	return n.value, nil
+}
+
+// nullText scans TEXT columns into a type implementing encoding.TextUnmarshaler, such as
+// netip.Addr or an enum type parsing its names. Other values, such as the number held by
+// an INTEGER column for an int based enum, are converted by the scanner of the kind of
+// the type, when it has one.
+type nullText struct {
+	typ   reflect.Type
+	kind  func() sql.Scanner
+	value any
+}
+
+// Scan implements the sql.Scanner interface for nullText.
+func (n *nullText) Scan(src any) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		n.value = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		if n.kind == nil {
+			return fmt.Errorf("cannot unmarshal %T value into %s", src, n.typ)
+		}
+		kind := n.kind()
+		if err := kind.Scan(src); err != nil {
+			return err
+		}
+		var err error
+		n.value, err = kind.(driver.Valuer).Value()
+		return err
+	}
+
+	ptr := reflect.New(n.typ)
+	if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText(b); err != nil {
+		return err
+	}
+	n.value = ptr.Elem().Interface()
+	return nil
+}
+
+// Value implements the driver.Valuer interface for nullText.
+func (n *nullText) Value() (driver.Value, error) {
+	return n.value, nil
+}
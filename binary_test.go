@@ -0,0 +1,76 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type Hash struct {
+	sum [4]byte
+}
+
+func (h Hash) MarshalBinary() ([]byte, error) {
+	return h.sum[:], nil
+}
+
+func (h *Hash) UnmarshalBinary(b []byte) error {
+	if len(b) != len(h.sum) {
+		return errors.New("invalid hash length")
+	}
+	copy(h.sum[:], b)
+	return nil
+}
+
+func (h Hash) String() string {
+	return hex.EncodeToString(h.sum[:])
+}
+
+func TestBinaryUnmarshaler(t *testing.T) {
+	var hash *Hash
+	require.NoError(t, sqlnull.New(&hash).Scan([]byte{0xde, 0xad, 0xbe, 0xef}))
+	require.Equal(t, "deadbeef", hash.String())
+
+	require.NoError(t, sqlnull.New(&hash).Scan(nil))
+	require.Nil(t, hash)
+
+	require.Error(t, sqlnull.New(&hash).Scan([]byte{1}))
+	require.Error(t, sqlnull.New(&hash).Scan(int64(1)))
+}
+
+func TestBinaryMarshalerInsert(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE [blobs] ([id] INTEGER, [hash] BLOB, [backup] BLOB)`)
+	require.NoError(t, err)
+
+	type Blob struct {
+		ID     int64
+		Hash   Hash
+		Backup *Hash
+	}
+
+	query, args, err := sqlnull.Insert("blobs", &Blob{ID: 1, Hash: Hash{sum: [4]byte{1, 2, 3, 4}}})
+	require.NoError(t, err)
+	_, err = db.Exec(query, args...)
+	require.NoError(t, err)
+
+	rows, err := db.Query("SELECT id, hash, backup FROM blobs")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	var blob Blob
+	require.NoError(t, sqlnull.ScanStruct(rows, &blob))
+	require.Equal(t, "01020304", blob.Hash.String())
+	require.Nil(t, blob.Backup)
+}
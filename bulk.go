@@ -0,0 +1,171 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"bufio"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bulkTimeLayout renders times in bulk files in a form both MySQL and Postgres accept.
+const bulkTimeLayout = "2006-01-02 15:04:05.999999"
+
+// bulkEscaper escapes the characters with a special meaning in bulk files.
+var bulkEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`, "\x00", `\0`)
+
+// BulkWriter writes rows in the tab separated text format read by MySQL LOAD DATA INFILE
+// and Postgres COPY FROM, with their default options: NULL is written as \N, and
+// backslashes, tabs, newlines, carriage returns and NUL bytes are escaped. Booleans are
+// written as 1 and 0, and times without a zone offset unless WithArgTimeLayout is set.
+// []byte values are written as they are, which MySQL reads into BLOB columns; files for
+// Postgres need WithPostgresBytea to load them into bytea columns.
+//
+//	w := sqlnull.NewBulkWriter(f)
+//	for _, user := range users {
+//		if err := w.WriteStruct(&user); err != nil {
+//			return err
+//		}
+//	}
+//	err = w.Flush()
+//
+// The file is then loaded with
+//
+//	LOAD DATA INFILE 'users.txt' INTO TABLE users (id, username, phone) -- MySQL
+//	COPY users (id, username, phone) FROM 'users.txt'                   -- Postgres
+type BulkWriter struct {
+	w             *bufio.Writer
+	config        *Config
+	postgresBytea bool
+}
+
+// BulkOption configures a BulkWriter.
+type BulkOption func(*BulkWriter)
+
+// WithPostgresBytea makes the BulkWriter write []byte values in the hex format of Postgres
+// bytea, \x followed by two hex digits per byte, so binary data loads with COPY FROM
+// unchanged. Files written with it are not meant for MySQL.
+func WithPostgresBytea() BulkOption {
+	return func(b *BulkWriter) {
+		b.postgresBytea = true
+	}
+}
+
+// NewBulkWriter returns a BulkWriter writing to w.
+func NewBulkWriter(w io.Writer, opts ...BulkOption) *BulkWriter {
+	return Default().NewBulkWriter(w, opts...)
+}
+
+// NewBulkWriter returns a BulkWriter writing to w, rendering values with the write options of c.
+func (c *Config) NewBulkWriter(w io.Writer, opts ...BulkOption) *BulkWriter {
+	b := &BulkWriter{w: bufio.NewWriter(w), config: c}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// WriteValues writes a single row holding values.
+func (b *BulkWriter) WriteValues(values ...any) error {
+	for i, v := range values {
+		if i > 0 {
+			b.w.WriteByte('\t')
+		}
+		field, err := b.field(v)
+		if err != nil {
+			return fmt.Errorf("bulk value #%d: %w", i, err)
+		}
+		b.w.WriteString(field)
+	}
+	return b.w.WriteByte('\n')
+}
+
+// WriteStruct writes the struct pointed to by v as a row, with the columns and values of Insert.
+func (b *BulkWriter) WriteStruct(v any) error {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("WriteStruct value must be a struct or a pointer to struct, got %T", v)
+	}
+	_, args, err := b.config.insertValues(val)
+	if err != nil {
+		return fmt.Errorf("WriteStruct %w", err)
+	}
+	return b.WriteValues(args...)
+}
+
+// WriteRows writes the remaining rows of rows and returns the number of rows written.
+func (b *BulkWriter) WriteRows(rows *sql.Rows) (int64, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	values := make([]any, len(columns))
+	targets := make([]any, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+
+	var n int64
+	for rows.Next() {
+		if err := rows.Scan(targets...); err != nil {
+			return n, err
+		}
+		if err := b.WriteValues(values...); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (b *BulkWriter) Flush() error {
+	return b.w.Flush()
+}
+
+// field renders v as an escaped bulk file field.
+func (b *BulkWriter) field(v any) (string, error) {
+	v, err := b.config.driverValue(v)
+	if err != nil {
+		return "", err
+	}
+	v, err = driver.DefaultParameterConverter.ConvertValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return `\N`, nil
+	case string:
+		return bulkEscaper.Replace(v), nil
+	case []byte:
+		if b.postgresBytea {
+			// COPY reads \\x as \x, which starts the hex format of bytea
+			return `\\x` + hex.EncodeToString(v), nil
+		}
+		return bulkEscaper.Replace(string(v)), nil
+	case bool:
+		if v {
+			return "1", nil
+		}
+		return "0", nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case time.Time:
+		return v.Format(bulkTimeLayout), nil
+	}
+	return "", fmt.Errorf("unsupported type %T", v)
+}
@@ -0,0 +1,62 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkWriter(t *testing.T) {
+	type User struct {
+		ID         int64
+		Username   string
+		Phone      *string
+		Active     bool
+		Score      *float64
+		VerifiedAt *time.Time
+	}
+	at := time.Date(2024, 8, 1, 10, 0, 0, 500000000, time.UTC)
+
+	var buf bytes.Buffer
+	w := sqlnull.NewBulkWriter(&buf)
+	require.NoError(t, w.WriteStruct(&User{ID: 1, Username: "john\tdoe\\", Phone: sqlnull.Ptr("line\nbreak"), Active: true, Score: sqlnull.Ptr(1.5), VerifiedAt: &at}))
+	require.NoError(t, w.WriteStruct(User{ID: 2, Username: "jane"}))
+	require.NoError(t, w.WriteValues(3, nil, []byte("a\rb"), uint8(7)))
+	require.NoError(t, w.Flush())
+
+	require.Equal(t, ""+
+		"1\tjohn\\tdoe\\\\\tline\\nbreak\t1\t1.5\t2024-08-01 10:00:00.5\n"+
+		"2\tjane\t\\N\t0\t\\N\t\\N\n"+
+		"3\t\\N\ta\\rb\t7\n", buf.String())
+
+	require.Error(t, w.WriteValues(struct{}{}))
+	require.Error(t, w.WriteStruct(42))
+}
+
+func TestBulkWriterPostgresBytea(t *testing.T) {
+	var buf bytes.Buffer
+	w := sqlnull.NewBulkWriter(&buf, sqlnull.WithPostgresBytea())
+	require.NoError(t, w.WriteValues(1, []byte("a\tb\\\n"), "c\td", []byte{}))
+	require.NoError(t, w.Flush())
+	require.Equal(t, "1\t\\\\x6109625c0a\tc\\td\t\\\\x\n", buf.String())
+}
+
+func TestBulkWriterRows(t *testing.T) {
+	db := makeusers(t)
+	rows, err := db.Query("SELECT id, first_name, last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var buf bytes.Buffer
+	w := sqlnull.NewConfig().NewBulkWriter(&buf)
+	n, err := w.WriteRows(rows)
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	require.Equal(t, int64(2), n)
+	require.Equal(t, "1\tjohn\tdoe\n2\tjane\t\\N\n", buf.String())
+}
@@ -0,0 +1,78 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+// nullByteArray scans BLOB columns into fixed-size byte arrays such as [16]byte or
+// [32]byte, holding UUIDs, hashes or keys, requiring the column to have exactly
+// the length of the array.
+type nullByteArray struct {
+	typ   reflect.Type
+	value any
+}
+
+// Scan implements the sql.Scanner interface for nullByteArray.
+func (n *nullByteArray) Scan(src any) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		n.value = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T value into %s", src, n.typ)
+	}
+	if len(b) != n.typ.Len() {
+		return fmt.Errorf("cannot scan %d bytes into %s", len(b), n.typ)
+	}
+
+	arr := reflect.New(n.typ).Elem()
+	reflect.Copy(arr, reflect.ValueOf(b))
+	n.value = arr.Interface()
+	return nil
+}
+
+// Value implements the driver.Valuer interface for nullByteArray.
+func (n *nullByteArray) Value() (driver.Value, error) {
+	return n.value, nil
+}
+
+// isByteArray reports whether t is a fixed-size byte array such as [16]byte.
+func isByteArray(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Uint8
+}
+
+// byteArrayTarget returns a scanner for target when it is a pointer to a byte array not
+// implementing sql.Scanner, which database/sql cannot scan into, setting the array to
+// zero on NULL.
+func byteArrayTarget(target any) sql.Scanner {
+	targetType := reflect.TypeOf(target)
+	if targetType.Kind() != reflect.Ptr || !isByteArray(targetType.Elem()) || reflect.ValueOf(target).IsNil() {
+		return nil
+	}
+	if _, ok := target.(sql.Scanner); ok {
+		return nil
+	}
+	return scanFunc(func(src any) error {
+		n := &nullByteArray{typ: targetType.Elem()}
+		if err := n.Scan(src); err != nil {
+			return err
+		}
+		arr := reflect.ValueOf(target).Elem()
+		if n.value == nil {
+			arr.Set(reflect.Zero(arr.Type()))
+			return nil
+		}
+		arr.Set(reflect.ValueOf(n.value))
+		return nil
+	})
+}
@@ -0,0 +1,39 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteArray(t *testing.T) {
+	db := makeusers(t)
+	id := [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	var plain [16]byte
+	require.NoError(t, db.QueryRow("SELECT ?", id[:]).Scan(sqlnull.Target(&plain)))
+	require.Equal(t, id, plain)
+	require.NoError(t, db.QueryRow("SELECT NULL").Scan(sqlnull.Target(&plain)))
+	require.Equal(t, [16]byte{}, plain)
+
+	var ptr *[16]byte
+	require.NoError(t, db.QueryRow("SELECT ?", id[:]).Scan(sqlnull.Target(&ptr)))
+	require.Equal(t, id, *ptr)
+	require.NoError(t, db.QueryRow("SELECT NULL").Scan(sqlnull.Target(&ptr)))
+	require.Nil(t, ptr)
+
+	var hash [32]byte
+	require.ErrorContains(t, db.QueryRow("SELECT ?", id[:]).Scan(sqlnull.Target(&hash)), "cannot scan 16 bytes into [32]uint8")
+
+	type Key struct {
+		ID   [16]byte
+		Hash *[32]byte
+	}
+	query, args, err := sqlnull.Insert("keys", &Key{ID: id})
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO keys (id, hash) VALUES (?, ?)", query)
+	require.Equal(t, []any{id[:], nil}, args)
+}
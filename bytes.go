@@ -0,0 +1,46 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// nullBytes scans BLOB and TEXT columns into []byte targets, and named byte slices such
+// as json.RawMessage or sql.RawBytes. The bytes are always copied, since drivers may reuse
+// the buffer of a []byte driver value once the next row is read, so a **sql.RawBytes target
+// keeps its value for good rather than only until the next call to Next.
+type nullBytes struct {
+	bytes []byte
+	valid bool
+}
+
+// Scan implements the sql.Scanner interface for nullBytes.
+func (n *nullBytes) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		n.bytes, n.valid = nil, false
+	case []byte:
+		// a non-nil slice, so an empty BLOB stays apart from NULL
+		n.bytes, n.valid = append([]byte{}, v...), true
+	case string:
+		n.bytes, n.valid = []byte(v), true
+	default:
+		// numbers, booleans and times in their text form, like database/sql does
+		var b sql.Null[[]byte]
+		if err := b.Scan(src); err != nil {
+			return err
+		}
+		n.bytes, n.valid = b.V, b.Valid
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface for nullBytes.
+func (n *nullBytes) Value() (driver.Value, error) {
+	if !n.valid {
+		return nil, nil
+	}
+	return n.bytes, nil
+}
@@ -0,0 +1,58 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBytesTargets(t *testing.T) {
+	db := makeusers(t)
+
+	var blob *[]byte
+	var raw *sql.RawBytes
+	var doc *json.RawMessage
+	var empty *[]byte
+	err := db.QueryRow("SELECT CAST('abc' AS BLOB), 'raw', '{\"a\":1}', x''").Scan(sqlnull.Scanner(&blob, &raw, &doc, &empty)...)
+	require.NoError(t, err)
+	require.Equal(t, []byte("abc"), *blob)
+	require.Equal(t, sql.RawBytes("raw"), *raw)
+	require.JSONEq(t, `{"a":1}`, string(*doc))
+	require.NotNil(t, *empty)
+	require.Empty(t, *empty)
+
+	require.NoError(t, db.QueryRow("SELECT NULL, NULL").Scan(sqlnull.Scanner(&blob, &raw)...))
+	require.Nil(t, blob)
+	require.Nil(t, raw)
+
+	// values are copied, so later rows cannot overwrite them
+	rows, err := db.Query("SELECT CAST(first_name AS BLOB) FROM users ORDER BY id")
+	require.NoError(t, err)
+	var got []*sql.RawBytes
+	for rows.Next() {
+		var b *sql.RawBytes
+		require.NoError(t, rows.Scan(sqlnull.Target(&b)))
+		got = append(got, b)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, sql.RawBytes("john"), *got[0])
+	require.Equal(t, sql.RawBytes("jane"), *got[1])
+
+	config := sqlnull.NewConfig(sqlnull.WithMaxBytes(2))
+	require.ErrorIs(t, db.QueryRow("SELECT CAST('abc' AS BLOB)").Scan(config.Target(&blob)), sqlnull.ErrTooLarge)
+
+	type File struct {
+		ID   int64
+		Data []byte
+	}
+	rows, err = db.Query("SELECT id, CASE id WHEN 1 THEN CAST('data' AS BLOB) END AS data FROM users ORDER BY id")
+	require.NoError(t, err)
+	var files []File
+	require.NoError(t, sqlnull.ScanAll(rows, &files))
+	require.Equal(t, []File{{1, []byte("data")}, {2, nil}}, files)
+}
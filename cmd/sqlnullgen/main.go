@@ -0,0 +1,374 @@
+// Command sqlnullgen generates reflection-free scanners for structs, with the ergonomics
+// of sqlnull.Scanner and the speed of hand-written scan code. For each type it emits
+// three methods on the pointer type:
+//
+//	func (v *User) Columns() []string                        // SELECT column list, in field order
+//	func (v *User) Targets() []any                           // scan targets, in the order of Columns
+//	func (v *User) ScanRow(scan func(dest ...any) error) error // scans a row and fills derived fields
+//
+// Fields follow the rules of sqlnull.ScanStruct: `db` tags name the columns, other fields
+// are named in snake_case, untagged embedded structs declared in the same package are
+// flattened and derive options are honored. Unlike ScanStruct, ScanRow matches columns by
+// position, so the query must select Columns in order:
+//
+//	query := "SELECT " + strings.Join((*User)(nil).Columns(), ", ") + " FROM users WHERE id = ?"
+//	var u User
+//	err = u.ScanRow(db.QueryRow(query, id).Scan)
+//
+// Fields of basic types, []byte and time.Time, and pointers to them, are scanned through
+// sqlnull.TargetOf and sqlnull.ValueOf, without reflection: NULL sets pointers to nil and
+// plain values to zero. Any other field, or one with a set, composite, json, rune or parse
+// tag option, falls back to sqlnull.FieldTarget at run time, which the sqlnull_lite build
+// leaves out.
+//
+// Use it with go generate:
+//
+//	//go:generate go run github.com/ceebydith/sqlnull/cmd/sqlnullgen -type=User,Order
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("sqlnullgen: ")
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; required")
+	output := flag.String("output", "", "output file name; default <first type>_sqlnull.go")
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	names := strings.Split(*typeNames, ",")
+	if *output == "" {
+		*output = strings.ToLower(names[0]) + "_sqlnull.go"
+	}
+	if !filepath.IsAbs(*output) {
+		*output = filepath.Join(dir, *output)
+	}
+
+	pkg, err := loadPackage(dir, *output)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(pkg, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// pkgInfo holds the declarations of the package a scanner is generated for.
+type pkgInfo struct {
+	name    string
+	structs map[string]*ast.StructType
+	methods map[string]*ast.FuncType // keyed by receiver type and method name, e.g. "User.FullName"
+}
+
+// loadPackage parses the non-test Go files of dir, skipping the output file.
+func loadPackage(dir, output string) (*pkgInfo, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &pkgInfo{structs: make(map[string]*ast.StructType), methods: make(map[string]*ast.FuncType)}
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || filepath.Clean(path) == filepath.Clean(output) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if pkg.name == "" {
+			pkg.name = file.Name.Name
+		}
+		pkg.add(file)
+	}
+	if pkg.name == "" {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return pkg, nil
+}
+
+// add records the struct types and methods declared in file.
+func (p *pkgInfo) add(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				if spec, ok := spec.(*ast.TypeSpec); ok {
+					if st, ok := spec.Type.(*ast.StructType); ok {
+						p.structs[spec.Name.Name] = st
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil || len(decl.Recv.List) != 1 {
+				continue
+			}
+			recv := decl.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if ident, ok := recv.(*ast.Ident); ok {
+				p.methods[ident.Name+"."+decl.Name.Name] = decl.Type
+			}
+		}
+	}
+}
+
+// field is a struct field taking part in scanning.
+type field struct {
+	path    string // selector from the receiver, e.g. Base.ID
+	column  string // empty for derived fields
+	tag     string
+	typ     string
+	options map[string]string
+}
+
+// generate returns the formatted source of the scanners for the named types.
+func generate(pkg *pkgInfo, names []string) ([]byte, error) {
+	var body bytes.Buffer
+	imports := map[string]bool{}
+	for _, name := range names {
+		st, ok := pkg.structs[name]
+		if !ok {
+			return nil, fmt.Errorf("struct type %s not found in package %s", name, pkg.name)
+		}
+		fields, err := pkg.fields(st, "")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := pkg.writeScanner(&body, name, fields, imports); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by sqlnullgen -type=%s; DO NOT EDIT.\n\n", strings.Join(names, ","))
+	fmt.Fprintf(&out, "package %s\n\n", pkg.name)
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for path := range imports {
+			paths = append(paths, path)
+		}
+		slices.Sort(paths)
+		out.WriteString("import (\n")
+		for i, path := range paths {
+			if i > 0 && strings.Contains(path, ".") && !strings.Contains(paths[i-1], ".") {
+				out.WriteString("\n")
+			}
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// fields returns the fields of st taking part in scanning, prefixing their paths with prefix.
+func (p *pkgInfo) fields(st *ast.StructType, prefix string) ([]field, error) {
+	var fields []field
+	for _, f := range st.Fields.List {
+		tag, hasTag := "", false
+		if f.Tag != nil {
+			raw, _ := strconv.Unquote(f.Tag.Value)
+			tag, hasTag = reflect.StructTag(raw).Lookup("db")
+		}
+		typ := types.ExprString(f.Type)
+
+		if len(f.Names) == 0 {
+			// embedded field, named after its type
+			name := strings.TrimPrefix(typ, "*")
+			if i := strings.LastIndexByte(name, '.'); i >= 0 {
+				name = name[i+1:]
+			}
+			if !hasTag && !strings.HasPrefix(typ, "*") {
+				if st, ok := p.structs[typ]; ok {
+					sub, err := p.fields(st, prefix+name+".")
+					if err != nil {
+						return nil, err
+					}
+					fields = append(fields, sub...)
+					continue
+				}
+				if strings.Contains(typ, ".") {
+					return nil, fmt.Errorf("embedded struct %s is not declared in this package", typ)
+				}
+			}
+			f.Names = []*ast.Ident{ast.NewIdent(name)}
+		}
+
+		for _, ident := range f.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			column, options := parseTag(tag)
+			if column == "-" {
+				if _, ok := options["derive"]; !ok {
+					continue
+				}
+				column = ""
+			} else if column == "" {
+				column = snakeCase(ident.Name)
+			}
+			fields = append(fields, field{path: prefix + ident.Name, column: column, tag: tag, typ: typ, options: options})
+		}
+	}
+	return fields, nil
+}
+
+// writeScanner writes the Columns, Targets and ScanRow methods of type name.
+func (p *pkgInfo) writeScanner(w *bytes.Buffer, name string, fields []field, imports map[string]bool) error {
+	fmt.Fprintf(w, "// Columns returns the columns scanned into %s, in the order of Targets.\n", name)
+	fmt.Fprintf(w, "func (v *%s) Columns() []string {\n\treturn []string{\n", name)
+	for _, f := range fields {
+		if f.column == "" {
+			continue
+		}
+		column := f.column
+		if expr, ok := f.options["expr"]; ok {
+			column = expr + " AS " + f.column
+		}
+		fmt.Fprintf(w, "\t\t%q,\n", column)
+	}
+	w.WriteString("\t}\n}\n\n")
+
+	fmt.Fprintf(w, "// Targets returns the scan targets for the fields of v, in the order of Columns.\n")
+	fmt.Fprintf(w, "func (v *%s) Targets() []any {\n\treturn []any{\n", name)
+	for _, f := range fields {
+		if f.column == "" {
+			continue
+		}
+		switch {
+		case hasScanOption(f.options) || !isBasic(strings.TrimPrefix(f.typ, "*")):
+			imports["github.com/ceebydith/sqlnull"] = true
+			fmt.Fprintf(w, "\t\tsqlnull.FieldTarget(&v.%s, %q),\n", f.path, f.tag)
+		case strings.HasPrefix(f.typ, "*"):
+			imports["github.com/ceebydith/sqlnull"] = true
+			fmt.Fprintf(w, "\t\tsqlnull.TargetOf(&v.%s),\n", f.path)
+		default:
+			imports["github.com/ceebydith/sqlnull"] = true
+			fmt.Fprintf(w, "\t\tsqlnull.ValueOf(&v.%s),\n", f.path)
+		}
+	}
+	w.WriteString("\t}\n}\n\n")
+
+	fmt.Fprintf(w, "// ScanRow scans a row selecting Columns, in order, into v with scan, typically\n")
+	fmt.Fprintf(w, "// row.Scan or rows.Scan, then fills the derived fields.\n")
+	fmt.Fprintf(w, "func (v *%s) ScanRow(scan func(dest ...any) error) error {\n", name)
+	w.WriteString("\tif err := scan(v.Targets()...); err != nil {\n\t\treturn err\n\t}\n")
+	for _, f := range fields {
+		method, ok := f.options["derive"]
+		if !ok {
+			continue
+		}
+		fn, ok := p.methods[name+"."+method]
+		if !ok {
+			return fmt.Errorf("derive method %s not found", method)
+		}
+		results := 0
+		if fn.Results != nil {
+			results = fn.Results.NumFields()
+		}
+		switch {
+		case len(fn.Params.List) != 0 || results < 1 || results > 2:
+			return fmt.Errorf("derive method %s must take no arguments and return a value and an optional error", method)
+		case results == 1:
+			fmt.Fprintf(w, "\tv.%s = v.%s()\n", f.path, method)
+		default:
+			imports["fmt"] = true
+			derived := string(unicode.ToLower(rune(method[0]))) + method[1:]
+			fmt.Fprintf(w, "\t%s, err := v.%s()\n", derived, method)
+			fmt.Fprintf(w, "\tif err != nil {\n\t\treturn fmt.Errorf(\"derive method %s: %%w\", err)\n\t}\n", method)
+			fmt.Fprintf(w, "\tv.%s = %s\n", f.path, derived)
+		}
+	}
+	w.WriteString("\treturn nil\n}\n\n")
+	return nil
+}
+
+// hasScanOption reports whether options hold a tag option changing how the field is scanned.
+func hasScanOption(options map[string]string) bool {
+	for _, name := range []string{"set", "composite", "json", "rune", "parse"} {
+		if _, ok := options[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isBasic reports whether typ is scanned by database/sql into a sql.Null[typ] without help.
+func isBasic(typ string) bool {
+	switch typ {
+	case "string", "bool", "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64",
+		"byte", "rune", "[]byte", "time.Time":
+		return true
+	}
+	return false
+}
+
+// parseTag splits a `db` struct tag into the column name and its options, like sqlnull does.
+func parseTag(tag string) (string, map[string]string) {
+	parts := strings.Split(tag, ",")
+	name := ""
+	if !strings.Contains(parts[0], "=") {
+		name, parts = strings.TrimSpace(parts[0]), parts[1:]
+	}
+
+	options := make(map[string]string)
+	for i, part := range parts {
+		key, value, _ := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		if key == "expr" {
+			value = strings.Join(append([]string{value}, parts[i+1:]...), ",")
+			options[key] = strings.TrimSpace(value)
+			break
+		}
+		options[key] = strings.TrimSpace(value)
+	}
+	return name, options
+}
+
+// snakeCase converts a Go field name to its snake_case column name, like sqlnull does.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
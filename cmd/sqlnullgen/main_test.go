@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	pkg, err := loadPackage("testdata", "")
+	require.NoError(t, err)
+	src, err := generate(pkg, []string{"User"})
+	require.NoError(t, err)
+
+	golden := filepath.Join("testdata", "user_sqlnull.go.golden")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, src, 0o644))
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(src))
+}
+
+func TestGenerateErrors(t *testing.T) {
+	pkg, err := loadPackage("testdata", "")
+	require.NoError(t, err)
+
+	_, err = generate(pkg, []string{"Missing"})
+	require.EqualError(t, err, "struct type Missing not found in package models")
+
+	pkg.methods["User.DisplayName"].Params.List = pkg.methods["User.MakeInitials"].Results.List
+	_, err = generate(pkg, []string{"User"})
+	require.EqualError(t, err, "User: derive method DisplayName must take no arguments and return a value and an optional error")
+
+	delete(pkg.methods, "User.DisplayName")
+	_, err = generate(pkg, []string{"User"})
+	require.EqualError(t, err, "User: derive method DisplayName not found")
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+type base struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+type Status string
+
+type User struct {
+	base
+	FirstName string
+	LastName  *string  `db:"surname"`
+	Email     *string  `db:"email_lower,expr=LOWER(email)"`
+	Status    Status   `db:"status"`
+	Perms     []string `db:"perms,set"`
+	Avatar    []byte   `db:"avatar"`
+	Age       *int     `db:"age"`
+	FullName  string   `db:"-,derive=DisplayName"`
+	Initials  string   `db:"-,derive=MakeInitials"`
+	Ignored   string   `db:"-"`
+	secret    string
+}
+
+func (u *User) DisplayName() string {
+	if u.LastName == nil {
+		return u.FirstName
+	}
+	return u.FirstName + " " + *u.LastName
+}
+
+func (u User) MakeInitials() (string, error) {
+	if u.FirstName == "" {
+		return "", errors.New("no first name")
+	}
+	return u.FirstName[:1], nil
+}
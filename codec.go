@@ -0,0 +1,71 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Codec is a serialization format the nullable wrappers Optional and Gob can be encoded
+// with, beyond the built-in JSON support. Codecs are provided by the sub packages wrapping
+// a third-party encoder, e.g. importing github.com/ceebydith/sqlnull/nullmsgpack for its
+// side effect registers "msgpack", keeping that dependency out of this package.
+type Codec struct {
+	// Marshal encodes v, encoding nil as the format's null.
+	Marshal func(v any) ([]byte, error)
+	// Unmarshal decodes data into the value pointed to by v, setting pointers to nil for null.
+	Unmarshal func(data []byte, v any) error
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{}
+)
+
+// RegisterCodec registers codec under name, replacing any codec already registered under it.
+func RegisterCodec(name string, codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[name] = codec
+}
+
+// lookupCodec returns the codec registered under name.
+func lookupCodec(name string) (Codec, error) {
+	codecsMu.RLock()
+	codec, ok := codecs[name]
+	codecsMu.RUnlock()
+	if !ok {
+		return Codec{}, fmt.Errorf("no %s codec registered, import github.com/ceebydith/sqlnull/null%s", name, name)
+	}
+	return codec, nil
+}
+
+// marshalNullable encodes v with the named codec, or null when valid is false.
+func marshalNullable[T any](name string, v T, valid bool) ([]byte, error) {
+	codec, err := lookupCodec(name)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return codec.Marshal(nil)
+	}
+	return codec.Marshal(v)
+}
+
+// unmarshalNullable decodes data with the named codec, reporting false for null.
+func unmarshalNullable[T any](name string, data []byte) (T, bool, error) {
+	var zero T
+	codec, err := lookupCodec(name)
+	if err != nil {
+		return zero, false, err
+	}
+	var p *T
+	if err := codec.Unmarshal(data, &p); err != nil {
+		return zero, false, err
+	}
+	if p == nil {
+		return zero, false, nil
+	}
+	return *p, true, nil
+}
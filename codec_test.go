@@ -0,0 +1,23 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecNotRegistered(t *testing.T) {
+	_, err := sqlnull.Some("lorem").MarshalMsgpack()
+	require.ErrorContains(t, err, "import github.com/ceebydith/sqlnull/nullmsgpack")
+
+	var gob sqlnull.Gob[string]
+	require.Error(t, gob.UnmarshalMsgpack([]byte{0xc0}))
+}
+
+func TestCodecNotRegisteredCBOR(t *testing.T) {
+	_, err := sqlnull.Some("lorem").MarshalCBOR()
+	require.ErrorContains(t, err, "import github.com/ceebydith/sqlnull/nullcbor")
+}
@@ -0,0 +1,65 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// WithCoercion enables cross-kind conversions database/sql rejects or leaves to the
+// driver, for schemas whose declared column types drifted from their contents:
+//
+//   - numbers and booleans scan into string targets in their text form,
+//   - booleans scan into numeric targets as 1 or 0,
+//   - any non-zero number, or numeric text, scans into bool targets as true.
+func WithCoercion() Option {
+	return func(c *Config) {
+		c.coercion = true
+	}
+}
+
+// coerce converts src into a value database/sql can store into a value of kind.
+func coerce(src any, kind reflect.Kind) any {
+	switch {
+	case kind == reflect.String:
+		switch v := src.(type) {
+		case int64:
+			return strconv.FormatInt(v, 10)
+		case float64:
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		case bool:
+			return strconv.FormatBool(v)
+		}
+	case kind == reflect.Bool:
+		switch v := src.(type) {
+		case int64:
+			return v != 0
+		case float64:
+			return v != 0
+		case string:
+			return coerceBool(v)
+		case []byte:
+			return coerceBool(string(v))
+		}
+	case isNumeric(kind):
+		if v, ok := src.(bool); ok {
+			if v {
+				return int64(1)
+			}
+			return int64(0)
+		}
+	}
+	return src
+}
+
+// coerceBool parses text as a boolean, falling back to comparing a number against zero.
+func coerceBool(s string) any {
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f != 0
+	}
+	return s
+}
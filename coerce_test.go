@@ -0,0 +1,47 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoercion(t *testing.T) {
+	var n *int16
+	require.Error(t, sqlnull.New(&n).Scan(true))
+
+	var b *bool
+	require.Error(t, sqlnull.New(&b).Scan(2.0))
+
+	var s *string
+	config := sqlnull.NewConfig(sqlnull.WithCoercion())
+	require.NoError(t, config.New(&s).Scan(int64(42)))
+	require.Equal(t, "42", *s)
+	require.NoError(t, config.New(&s).Scan(1.5))
+	require.Equal(t, "1.5", *s)
+	require.NoError(t, config.New(&s).Scan(true))
+	require.Equal(t, "true", *s)
+
+	require.NoError(t, config.New(&b).Scan(int64(2)))
+	require.True(t, *b)
+	require.NoError(t, config.New(&b).Scan(0.0))
+	require.False(t, *b)
+	require.NoError(t, config.New(&b).Scan("3"))
+	require.True(t, *b)
+	require.NoError(t, config.New(&b).Scan([]byte("false")))
+	require.False(t, *b)
+	require.Error(t, config.New(&b).Scan("maybe"))
+
+	require.NoError(t, config.New(&n).Scan(true))
+	require.Equal(t, int16(1), *n)
+
+	var f *float64
+	require.NoError(t, config.New(&f).Scan(false))
+	require.Equal(t, 0.0, *f)
+
+	require.NoError(t, config.New(&f).Scan(nil))
+	require.Nil(t, f)
+}
@@ -0,0 +1,66 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNull is returned under version 2 behavior when a NULL column is scanned into a struct
+// field that cannot hold NULL, such as a plain int64 or string.
+var ErrNull = errors.New("NULL scanned into non-nullable field")
+
+// Compat names a version 1 behavior kept under WithVersion(2).
+type Compat uint
+
+const (
+	// CompatV1ZeroValue keeps setting non-pointer struct fields to their zero value when
+	// their column is NULL, instead of failing with ErrNull.
+	CompatV1ZeroValue Compat = 1 << iota
+	// CompatV1Errors keeps returning conversion errors as they come from database/sql,
+	// instead of wrapping them with the driver and target types.
+	CompatV1Errors
+)
+
+// WithVersion selects the scan semantics of the given version. Version 1, the default, is the
+// behavior sqlnull always had. Version 2 gathers the stricter semantics that will become the
+// default in the next major release:
+//
+//   - ScanStruct fails with ErrNull when a NULL column meets a non-pointer field,
+//     rather than silently setting the zero value;
+//   - conversion errors name the driver type and target type they failed between,
+//     and still unwrap to the original error.
+//
+// Large codebases can adopt version 2 and keep individual version 1 behaviors with
+// WithCompat until the code relying on them is updated:
+//
+//	config := sqlnull.NewConfig(sqlnull.WithVersion(2), sqlnull.WithCompat(sqlnull.CompatV1ZeroValue))
+func WithVersion(version int) Option {
+	return func(c *Config) {
+		c.version = version
+	}
+}
+
+// WithCompat keeps the given version 1 behaviors under WithVersion(2).
+func WithCompat(flags ...Compat) Option {
+	return func(c *Config) {
+		for _, flag := range flags {
+			c.compat |= flag
+		}
+	}
+}
+
+// changed reports whether the version 2 behavior guarded by flag is in effect.
+func (c *Config) changed(flag Compat) bool {
+	return c.version >= 2 && c.compat&flag == 0
+}
+
+// wrapError adds the types involved to a conversion error under version 2 behavior.
+func (c *Config) wrapError(err error, src any, target reflect.Type) error {
+	if err == nil || !c.changed(CompatV1Errors) {
+		return err
+	}
+	return fmt.Errorf("sqlnull: converting %T to %s: %w", src, target, err)
+}
@@ -0,0 +1,61 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type compatUser struct {
+	ID       int64
+	LastName string
+}
+
+func scanCompat(t *testing.T, config *sqlnull.Config, id int) (compatUser, error) {
+	db := makeusers(t)
+	rows, err := db.Query("SELECT id, last_name FROM users WHERE id = ?", id)
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	var user compatUser
+	return user, config.ScanStruct(rows, &user)
+}
+
+func TestVersion2ZeroValue(t *testing.T) {
+	// version 1 sets the zero value
+	user, err := scanCompat(t, sqlnull.NewConfig(), 2)
+	require.NoError(t, err)
+	require.Equal(t, compatUser{ID: 2}, user)
+
+	_, err = scanCompat(t, sqlnull.NewConfig(sqlnull.WithVersion(2)), 2)
+	require.ErrorIs(t, err, sqlnull.ErrNull)
+
+	_, err = scanCompat(t, sqlnull.NewConfig(sqlnull.WithVersion(2), sqlnull.WithCompat(sqlnull.CompatV1ZeroValue)), 2)
+	require.NoError(t, err)
+
+	_, err = scanCompat(t, sqlnull.NewConfig(sqlnull.WithVersion(2)), 1)
+	require.NoError(t, err)
+}
+
+func TestVersion2Errors(t *testing.T) {
+	db := makeusers(t)
+	scan := func(config *sqlnull.Config) error {
+		var id *bool
+		return db.QueryRow("SELECT first_name FROM users WHERE id = 1").Scan(config.Target(&id))
+	}
+
+	err := scan(sqlnull.NewConfig())
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "sqlnull: converting")
+
+	err = scan(sqlnull.NewConfig(sqlnull.WithVersion(2)))
+	require.ErrorContains(t, err, "sqlnull: converting string to *bool")
+	require.ErrorContains(t, err, `couldn't convert "john" into type bool`)
+
+	err = scan(sqlnull.NewConfig(sqlnull.WithVersion(2), sqlnull.WithCompat(sqlnull.CompatV1Errors)))
+	require.NotContains(t, err.Error(), "sqlnull: converting")
+}
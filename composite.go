@@ -0,0 +1,178 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Composite returns a scanner filling the struct dst points to from a Postgres composite
+// value, such as a row-valued column or a function returning a record. The driver value
+// may be the record text form, e.g. (42,"Main St, 5",), with an empty attribute meaning
+// NULL, a []any holding the attributes in order, or a map[string]any keyed by attribute
+// name, as pgx returns for registered composite types. Attributes fill the fields in
+// ScanStruct order, converted like columns are, so nullable attributes go into pointer or
+// sql.Null* fields, and struct fields holding a nested composite are filled the same way.
+// dst may also point to a pointer to struct, which NULL leaves nil. ScanStruct does the
+// same for fields tagged with the composite option: `db:"address,composite"`.
+//
+//	var addr Address
+//	err = db.QueryRow("SELECT home_address FROM customers WHERE id=$1", id).Scan(sqlnull.Composite(&addr))
+func Composite(dst any) sql.Scanner {
+	return Default().Composite(dst)
+}
+
+// Composite returns a scanner filling the struct dst points to from a Postgres composite value.
+func (c *Config) Composite(dst any) sql.Scanner {
+	return scanFunc(func(src any) error {
+		val := reflect.ValueOf(dst)
+		if val.Kind() != reflect.Ptr || val.IsNil() {
+			return fmt.Errorf("Composite destination must be a non-nil pointer, got %T", dst)
+		}
+		return c.scanComposite(val.Elem(), src)
+	})
+}
+
+// scanComposite stores the composite value src into dst.
+func (c *Config) scanComposite(dst reflect.Value, src any) error {
+	if src == nil {
+		dst.SetZero()
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr {
+		ptr := reflect.New(dst.Type().Elem())
+		if err := c.scanComposite(ptr.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	}
+	if dst.Kind() != reflect.Struct {
+		return fmt.Errorf("composite value needs a struct destination, got %s", dst.Type())
+	}
+
+	var fields []structField
+	for _, field := range structFields(dst.Type()) {
+		if field.name != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	dst.SetZero()
+	switch v := src.(type) {
+	case string, []byte:
+		s, _ := text(v)
+		attrs, err := parseComposite(s)
+		if err != nil {
+			return err
+		}
+		if err := c.compositeFields(dst, fields, attrs); err != nil {
+			return err
+		}
+	case []any:
+		if err := c.compositeFields(dst, fields, v); err != nil {
+			return err
+		}
+	case map[string]any:
+		for name, attr := range v {
+			i := slices.IndexFunc(fields, func(field structField) bool { return field.match(name) })
+			if i < 0 {
+				return fmt.Errorf("missing destination for composite attribute %q in %s", name, dst.Type())
+			}
+			if err := c.compositeField(dst, fields[i], attr); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("cannot scan %T value into composite %s", src, dst.Type())
+	}
+	return derive(dst.Addr(), structFields(dst.Type()))
+}
+
+// compositeFields stores the attributes of a composite value into the fields in order.
+func (c *Config) compositeFields(dst reflect.Value, fields []structField, attrs []any) error {
+	if len(attrs) != len(fields) {
+		return fmt.Errorf("composite value has %d attributes, %s has %d fields", len(attrs), dst.Type(), len(fields))
+	}
+	for i, attr := range attrs {
+		if err := c.compositeField(dst, fields[i], attr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compositeField stores a single attribute into field of dst.
+func (c *Config) compositeField(dst reflect.Value, field structField, attr any) error {
+	target := dst.FieldByIndex(field.index).Addr().Interface()
+	scanner, err := c.fieldScanner(field, target)
+	if err != nil {
+		return err
+	}
+	if _, ok := scanner.(sql.Scanner); !ok && isStruct(reflect.TypeOf(target).Elem()) {
+		// a struct without a conversion of its own holds a nested composite
+		scanner = c.Composite(target)
+	}
+	if s, ok := scanner.(sql.Scanner); ok {
+		err = s.Scan(attr)
+	} else {
+		err = c.scan(target, attr)
+	}
+	if err != nil {
+		return fmt.Errorf("composite attribute %q: %w", field.name, err)
+	}
+	return nil
+}
+
+// isStruct reports whether t is a struct or a pointer to one.
+func isStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// parseComposite splits the text form of a composite value into its attributes: nil for
+// NULL, the unescaped text otherwise.
+func parseComposite(s string) ([]any, error) {
+	if len(s) < 2 || s[0] != '(' || s[len(s)-1] != ')' {
+		return nil, fmt.Errorf("malformed composite value %q", s)
+	}
+	body := s[1 : len(s)-1]
+
+	var attrs []any
+	var b strings.Builder
+	quoted, inQuotes := false, false
+	attr := func() any {
+		defer b.Reset()
+		if b.Len() == 0 && !quoted {
+			return nil
+		}
+		return b.String()
+	}
+	for i := 0; i < len(body); i++ {
+		switch ch := body[i]; {
+		case ch == '\\' && i+1 < len(body):
+			i++
+			b.WriteByte(body[i])
+		case ch == '"' && inQuotes && i+1 < len(body) && body[i+1] == '"':
+			i++
+			b.WriteByte('"')
+		case ch == '"':
+			inQuotes, quoted = !inQuotes, true
+		case ch == ',' && !inQuotes:
+			attrs = append(attrs, attr())
+			quoted = false
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("malformed composite value %q: unterminated quote", s)
+	}
+	return append(attrs, attr()), nil
+}
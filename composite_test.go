@@ -0,0 +1,73 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type compositePoint struct {
+	X, Y int64
+}
+
+type compositeAddress struct {
+	Street string
+	Number *int64
+	Zip    *string
+	Geo    *compositePoint
+}
+
+func TestComposite(t *testing.T) {
+	var addr compositeAddress
+	require.NoError(t, sqlnull.Composite(&addr).Scan(`("Main St, ""Old"" Town",5,,"(1,2)")`))
+	require.Equal(t, `Main St, "Old" Town`, addr.Street)
+	require.Equal(t, int64(5), *addr.Number)
+	require.Nil(t, addr.Zip)
+	require.Equal(t, &compositePoint{1, 2}, addr.Geo)
+
+	require.NoError(t, sqlnull.Composite(&addr).Scan([]byte(`(,,"",)`)))
+	require.Equal(t, compositeAddress{Zip: sqlnull.Ptr("")}, addr)
+
+	require.NoError(t, sqlnull.Composite(&addr).Scan([]any{"Elm", int64(7), nil, []any{int64(3), int64(4)}}))
+	require.Equal(t, compositeAddress{Street: "Elm", Number: sqlnull.Ptr(int64(7)), Geo: &compositePoint{3, 4}}, addr)
+
+	require.NoError(t, sqlnull.Composite(&addr).Scan(map[string]any{"street": "Oak", "zip": "12345"}))
+	require.Equal(t, compositeAddress{Street: "Oak", Zip: sqlnull.Ptr("12345")}, addr)
+
+	ptr := &compositeAddress{}
+	require.NoError(t, sqlnull.Composite(&ptr).Scan(nil))
+	require.Nil(t, ptr)
+
+	require.ErrorContains(t, sqlnull.Composite(&addr).Scan(`(1,2)`), "2 attributes")
+	require.ErrorContains(t, sqlnull.Composite(&addr).Scan(`("Main,,,)`), "unterminated quote")
+	require.ErrorContains(t, sqlnull.Composite(&addr).Scan(`(x,y,,)`), `attribute "number"`)
+	require.ErrorContains(t, sqlnull.Composite(&addr).Scan(map[string]any{"city": "x"}), `"city"`)
+}
+
+func TestScanStructComposite(t *testing.T) {
+	db := makeusers(t)
+
+	type Customer struct {
+		ID      int64
+		Address *compositeAddress `db:"address,composite"`
+	}
+
+	rows, err := db.Query(`SELECT id, CASE id WHEN 1 THEN '(Main,5,,)' END AS address FROM users ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var got []Customer
+	for rows.Next() {
+		var customer Customer
+		require.NoError(t, sqlnull.ScanStruct(rows, &customer))
+		got = append(got, customer)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []Customer{
+		{ID: 1, Address: &compositeAddress{Street: "Main", Number: sqlnull.Ptr(int64(5))}},
+		{ID: 2},
+	}, got)
+}
@@ -0,0 +1,262 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"maps"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// Config holds the settings used to build NullValue wrappers.
+//
+// A Config is never modified after NewConfig returns and Scan only reads
+// from it, so a single Config can be shared by any number of goroutines
+// running parallel queries. Each goroutine should still build its own
+// targets, since the wrapped variables themselves are written on Scan.
+type Config struct {
+	trace           *Trace
+	profile         *Profile
+	tolerantNumbers bool
+	allErrors       bool
+	maxBytes        int
+	truncateBytes   bool
+	sscanFallback   bool
+	coercion        bool
+	exactNumerics   bool
+	timePrecision   time.Duration
+	timeRound       bool
+	timeLayouts     []string
+	zeroDatesAsNull bool
+	utc             bool
+	resetTargets    bool
+	durationUnit    time.Duration
+	strictRanges    bool
+
+	argTimeLayout    string
+	argTimePrecision time.Duration
+	zeroTimeAsNull   bool
+	zeroAsNull       map[reflect.Kind]bool
+
+	version int
+	compat  Compat
+}
+
+// Option configures a Config.
+type Option func(*Config)
+
+// defaultConfig holds the Config used by the package level functions, see SetDefault.
+var defaultConfig atomic.Pointer[Config]
+
+func init() {
+	defaultConfig.Store(NewConfig())
+}
+
+// Default returns the Config used by the package level Target, Scanner, New and the other
+// package level helpers.
+func Default() *Config {
+	return defaultConfig.Load()
+}
+
+// SetDefault replaces the Config used by the package level functions, so an application
+// can set its policy once at startup:
+//
+//	sqlnull.SetDefault(sqlnull.NewConfig(sqlnull.WithUTC(), sqlnull.WithTolerantNumbers()))
+//
+// Targets already built keep the Config they were built with. A nil c restores the
+// built-in defaults.
+func SetDefault(c *Config) {
+	if c == nil {
+		c = NewConfig()
+	}
+	defaultConfig.Store(c)
+}
+
+// NewConfig creates a new Config with the given options applied.
+func NewConfig(opts ...Option) *Config {
+	c := &Config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// With returns a copy of c with opts applied on top of its settings, leaving c untouched.
+// It overrides the defaults for a single call site:
+//
+//	err = row.Scan(sqlnull.Default().With(sqlnull.WithSscanFallback()).Scanner(&id, &code)...)
+func (c *Config) With(opts ...Option) *Config {
+	clone := *c
+	clone.zeroAsNull = maps.Clone(c.zeroAsNull)
+	for _, opt := range opts {
+		opt(&clone)
+	}
+	return &clone
+}
+
+// Target returns a NullValue wrapper if the target is valid, otherwise returns the target itself.
+func (c *Config) Target(target any) any {
+	wrapped, _ := c.target(target)
+	return wrapped
+}
+
+// target implements Target and TargetE. A target returned unchanged comes with the error
+// telling why no NullValue wrapper could be built for it.
+func (c *Config) target(target any) (any, error) {
+	if target == nil {
+		return new(any), nil
+	}
+	if isStdNull(target) {
+		return target, nil
+	}
+	if scanner := c.setterTarget(target); scanner != nil {
+		return scanner, nil
+	}
+	if scanner := convertedTarget(target); scanner != nil {
+		return scanner, nil
+	}
+	if scanner := c.optionTarget(target); scanner != nil {
+		return scanner, nil
+	}
+	if scanner := byteArrayTarget(target); scanner != nil {
+		return scanner, nil
+	}
+	if scanner := c.arrayTarget(target); scanner != nil {
+		return scanner, nil
+	}
+	if d, ok := target.(*time.Duration); ok && d != nil {
+		return c.durationTarget(d), nil
+	}
+	if t, ok := target.(*time.Time); ok && t != nil && (len(c.timeLayouts) > 0 || c.zeroDatesAsNull) {
+		return c.timeTarget(t), nil
+	}
+	_, _, err := c.validate(target)
+	if err == nil {
+		return c.New(target), nil
+	}
+	return target, err
+}
+
+// Scanner wraps multiple targets with NullValue.
+func (c *Config) Scanner(targets ...any) []any {
+	var result []any
+
+	for i, target := range targets {
+		result = append(result, c.traced(i, "", target, c.Target(target)))
+	}
+
+	return result
+}
+
+// New creates a new NullValue for a given target. The converter for the target type is
+// picked once here rather than on every Scan.
+func (c *Config) New(target any) *NullValue {
+	v := &NullValue{
+		target: target,
+		config: c,
+	}
+	if target != nil {
+		v.targetType = reflect.TypeOf(target)
+		v.newNull, _ = c.converter(v.targetType)
+	}
+	return v
+}
+
+// validate checks the target like the package level validate, adding the converters enabled on c.
+func (c *Config) validate(target any) (sql.Scanner, reflect.Type, error) {
+	targetType := reflect.TypeOf(target)
+	newNull, err := c.converter(targetType)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newNull(), targetType, nil
+}
+
+// converter is the package level converter, adding the converters enabled on c.
+func (c *Config) converter(targetType reflect.Type) (func() sql.Scanner, error) {
+	newNull, err := converter(targetType)
+	if err == nil || !c.sscanFallback {
+		return newNull, err
+	}
+	if targetType.Kind() == reflect.Ptr && targetType.Elem().Kind() == reflect.Ptr {
+		return func() sql.Scanner { return &nullSscan{typ: targetType.Elem().Elem()} }, nil
+	}
+	return nil, err
+}
+
+// prepare adjusts a driver value before it is scanned into a value of type elem.
+func (c *Config) prepare(src any, elem reflect.Type) (any, error) {
+	src, _, err := c.limit(src)
+	if err != nil {
+		return nil, err
+	}
+	if reflect.PointerTo(elem).Implements(setterType) {
+		// SetValue receives the driver value as setterTarget passes it
+		return c.finish(src), nil
+	}
+	if elem == durationType {
+		return c.scaleDuration(src), nil
+	}
+	if elem == timeType {
+		if c.zeroDatesAsNull && isZeroDate(src) {
+			return nil, nil
+		}
+		return c.parseTime(src)
+	}
+	if c.tolerantNumbers && isNumeric(elem.Kind()) {
+		src = tolerantNumber(src)
+	}
+	if c.coercion {
+		src = coerce(src, elem.Kind())
+	}
+	if c.exactNumerics {
+		if err := exactFloat(src, elem.Kind()); err != nil {
+			return nil, err
+		}
+	}
+	return src, nil
+}
+
+// finish adjusts a converted value right before it is assigned to the target.
+func (c *Config) finish(v any) any {
+	if t, ok := v.(time.Time); ok {
+		return c.adjustTime(t)
+	}
+	return v
+}
+
+// scan assigns src to a single target. Targets accepted by Target are wrapped
+// with NullValue, pointers to plain values are filled with the zero value on
+// NULL, and anything else is handled when it implements sql.Scanner or is *any.
+func (c *Config) scan(target any, src any) error {
+	switch t := c.Target(target).(type) {
+	case sql.Scanner:
+		return t.Scan(src)
+	case *any:
+		src, _, err := c.limit(src)
+		if err != nil {
+			return err
+		}
+		*t = src
+		return nil
+	}
+
+	val := reflect.ValueOf(target)
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		ptr := reflect.New(val.Type())
+		ptr.Elem().Set(val)
+		if _, _, err := c.validate(ptr.Interface()); err == nil {
+			if err := c.New(ptr.Interface()).Scan(src); err != nil {
+				return err
+			}
+			if ptr.Elem().IsNil() {
+				val.Elem().Set(reflect.Zero(val.Type().Elem()))
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("NullValue for %T type is not supported", target)
+}
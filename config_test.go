@@ -0,0 +1,85 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigSharedAcrossGoroutines(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file::memory:?cache=shared")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE [shared] ([id] INTEGER, [name] TEXT)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO shared (id, name) VALUES (1, 'one'), (2, NULL)`)
+	require.NoError(t, err)
+
+	config := sqlnull.NewConfig()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := int64(1); id <= 2; id++ {
+				var gotID *int64
+				var name *string
+				err := db.QueryRow("SELECT id, name FROM shared WHERE id=?", id).Scan(config.Scanner(&gotID, &name)...)
+				if assert.NoError(t, err) {
+					assert.Equal(t, id, *gotID)
+					assert.Equal(t, id == 2, name == nil)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSetDefault(t *testing.T) {
+	t.Cleanup(func() { sqlnull.SetDefault(nil) })
+
+	db := makeusers(t)
+	var lastName *string
+	require.NoError(t, db.QueryRow("SELECT last_name FROM users WHERE id=1").Scan(sqlnull.Target(&lastName)))
+
+	sqlnull.SetDefault(sqlnull.NewConfig(sqlnull.WithMaxBytes(2)))
+	err := db.QueryRow("SELECT last_name FROM users WHERE id=1").Scan(sqlnull.Target(&lastName))
+	require.ErrorIs(t, err, sqlnull.ErrTooLarge)
+
+	// per call overrides build on the default
+	err = db.QueryRow("SELECT last_name FROM users WHERE id=1").Scan(sqlnull.Default().With(sqlnull.WithMaxBytes(3)).Target(&lastName))
+	require.NoError(t, err)
+	require.Equal(t, "doe", *lastName)
+
+	sqlnull.SetDefault(nil)
+	require.NoError(t, db.QueryRow("SELECT last_name FROM users WHERE id=1").Scan(sqlnull.Target(&lastName)))
+}
+
+func TestConfigWith(t *testing.T) {
+	base := sqlnull.NewConfig(sqlnull.WithZeroAsNull(reflect.Int64))
+	derived := base.With(sqlnull.WithZeroAsNull(reflect.String))
+
+	_, args, err := base.Insert("users", &struct {
+		ID   int64
+		Name string
+	}{})
+	require.NoError(t, err)
+	require.Equal(t, []any{nil, ""}, args)
+
+	_, args, err = derived.Insert("users", &struct {
+		ID   int64
+		Name string
+	}{})
+	require.NoError(t, err)
+	require.Equal(t, []any{nil, nil}, args)
+}
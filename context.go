@@ -0,0 +1,26 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import "context"
+
+// configKey is the context key holding the Config attached by WithConfig.
+type configKey struct{}
+
+// WithConfig returns a copy of ctx carrying c. The package level helpers taking a context,
+// such as EachCtx, use it in place of the defaults, so request-scoped behavior like time
+// zone handling or strictness flows through without passing a Config around:
+//
+//	ctx = sqlnull.WithConfig(ctx, sqlnull.NewConfig(sqlnull.WithUTC()))
+//	err = sqlnull.EachCtx(ctx, rows, targets, fn)
+func WithConfig(ctx context.Context, c *Config) context.Context {
+	return context.WithValue(ctx, configKey{}, c)
+}
+
+// ConfigFromContext returns the Config attached to ctx by WithConfig, or the default Config.
+func ConfigFromContext(ctx context.Context) *Config {
+	if c, ok := ctx.Value(configKey{}).(*Config); ok && c != nil {
+		return c
+	}
+	return Default()
+}
@@ -0,0 +1,26 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithConfig(t *testing.T) {
+	config := sqlnull.NewConfig(sqlnull.WithMaxBytes(2))
+	ctx := sqlnull.WithConfig(context.Background(), config)
+	require.Same(t, config, sqlnull.ConfigFromContext(ctx))
+	require.NotNil(t, sqlnull.ConfigFromContext(context.Background()))
+
+	db := makeusers(t)
+	rows, err := db.Query("SELECT last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+
+	var lastName *string
+	err = sqlnull.EachCtx(ctx, rows, []any{&lastName}, func() error { return nil })
+	require.ErrorIs(t, err, sqlnull.ErrTooLarge)
+}
@@ -0,0 +1,135 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Convert builds a Dst from src, matching struct fields by `db` tag or field name
+// the same way ScanStruct matches columns. Values are converted nil-aware between
+// pointers, Null wrappers such as sql.NullString or sql.Null[T] and plain values:
+// a nil pointer or invalid Null becomes nil, invalid or the zero value, and a
+// present value is wrapped or unwrapped as the destination field requires.
+// Nested structs and slices are converted field by field and element by element. Destination fields without a
+// matching source field are left at their zero value.
+//
+//	type User struct {
+//		ID    int64
+//		Phone sql.NullString
+//	}
+//
+//	type UserDTO struct {
+//		ID    int64   `json:"id"`
+//		Phone *string `json:"phone"`
+//	}
+//
+//	dto, err := sqlnull.Convert[UserDTO](user)
+func Convert[Dst, Src any](src Src) (Dst, error) {
+	var dst Dst
+	err := convertValue(reflect.ValueOf(&dst).Elem(), reflect.ValueOf(&src).Elem())
+	return dst, err
+}
+
+// convertValue stores src into dst, unwrapping pointers and Null wrappers on the
+// source side and wrapping them again on the destination side.
+func convertValue(dst, src reflect.Value) error {
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	switch {
+	case src.Kind() == reflect.Ptr || src.Kind() == reflect.Interface:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		return convertValue(dst, src.Elem())
+	case isNullWrapper(src.Type()):
+		if !src.Field(1).Bool() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		return convertValue(dst, src.Field(0))
+	}
+
+	switch {
+	case dst.Kind() == reflect.Ptr:
+		ptr := reflect.New(dst.Type().Elem())
+		if err := convertValue(ptr.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+	case isNullWrapper(dst.Type()):
+		if err := convertValue(dst.Field(0), src); err != nil {
+			return err
+		}
+		dst.Field(1).SetBool(true)
+	case convertible(src.Type(), dst.Type()):
+		dst.Set(src.Convert(dst.Type()))
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct:
+		return convertStruct(dst, src, false)
+	case src.Kind() == reflect.Slice && dst.Kind() == reflect.Slice:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := convertValue(slice.Index(i), src.Index(i)); err != nil {
+				return fmt.Errorf("index %d: %w", i, err)
+			}
+		}
+		dst.Set(slice)
+	default:
+		return fmt.Errorf("cannot convert %s to %s", src.Type(), dst.Type())
+	}
+	return nil
+}
+
+// convertStruct converts the fields of src into the matching fields of dst,
+// leaving a field untouched when skipNil is set and its source is nil.
+func convertStruct(dst, src reflect.Value, skipNil bool) error {
+	srcFields := structFields(src.Type())
+	for _, field := range structFields(dst.Type()) {
+		if field.name == "" {
+			continue
+		}
+		for _, from := range srcFields {
+			if !from.match(field.name) {
+				continue
+			}
+			value := src.FieldByIndex(from.index)
+			if skipNil && isNil(value) {
+				break
+			}
+			if err := convertValue(dst.FieldByIndex(field.index), value); err != nil {
+				return fmt.Errorf("field %s: %w", dst.Type().FieldByIndex(field.index).Name, err)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// isNullWrapper reports whether t has the shape of sql.NullString and friends:
+// a value field followed by a Valid bool.
+func isNullWrapper(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.NumField() == 2 &&
+		t.Field(0).IsExported() && t.Field(1).Name == "Valid" && t.Field(1).Type.Kind() == reflect.Bool
+}
+
+// convertible reports whether a value of type from may be converted to type to,
+// refusing the integer to string conversion that would yield a rune.
+func convertible(from, to reflect.Type) bool {
+	if to.Kind() == reflect.String {
+		switch from.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return false
+		}
+	}
+	return from.ConvertibleTo(to)
+}
@@ -0,0 +1,89 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type convertModel struct {
+	ID        int64
+	Username  string
+	Phone     sql.NullString
+	Age       sql.Null[int32]
+	CreatedAt time.Time
+	Verified  *time.Time `db:"verified_at"`
+	Address   convertModelAddress
+}
+
+type convertModelAddress struct {
+	City sql.NullString
+}
+
+type convertDTO struct {
+	ID         int64
+	Username   *string
+	Phone      *string
+	Age        int64
+	CreatedAt  *time.Time
+	VerifiedAt sql.NullTime
+	Address    convertDTOAddress
+	Ignored    string
+}
+
+type convertDTOAddress struct {
+	City string
+}
+
+func TestConvert(t *testing.T) {
+	now := time.Now()
+
+	model := convertModel{
+		ID:        1,
+		Username:  "johndoe",
+		Phone:     sql.NullString{String: "123456789", Valid: true},
+		CreatedAt: now,
+		Address:   convertModelAddress{City: sql.NullString{String: "Jakarta", Valid: true}},
+	}
+	dto, err := sqlnull.Convert[convertDTO](model)
+	require.NoError(t, err)
+	require.Equal(t, convertDTO{
+		ID:        1,
+		Username:  sqlnull.Ptr("johndoe"),
+		Phone:     sqlnull.Ptr("123456789"),
+		CreatedAt: &now,
+		Address:   convertDTOAddress{City: "Jakarta"},
+	}, dto)
+
+	// and back again, through pointers on both sides
+	dto.Age = 30
+	dto.Phone = nil
+	dto.VerifiedAt = sql.NullTime{Time: now, Valid: true}
+	back, err := sqlnull.Convert[*convertModel](&dto)
+	require.NoError(t, err)
+	require.Equal(t, &convertModel{
+		ID:        1,
+		Username:  "johndoe",
+		Age:       sql.Null[int32]{V: 30, Valid: true},
+		CreatedAt: now,
+		Verified:  &now,
+		Address:   convertModelAddress{City: sql.NullString{String: "Jakarta", Valid: true}},
+	}, back)
+}
+
+func TestConvertErrors(t *testing.T) {
+	_, err := sqlnull.Convert[struct{ ID string }](struct{ ID int64 }{ID: 65})
+	require.ErrorContains(t, err, "field ID: cannot convert int64 to string")
+
+	_, err = sqlnull.Convert[struct{ At time.Time }](struct{ At *bool }{At: sqlnull.Ptr(true)})
+	require.Error(t, err)
+
+	// nil sources never fail, whatever the destination type
+	_, err = sqlnull.Convert[struct{ At time.Time }](struct{ At *bool }{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,103 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ConverterFunc converts a non-NULL driver value into a value of the type it is registered
+// for. The result is converted to that type when needed, and a nil result means NULL.
+type ConverterFunc func(src any) (any, error)
+
+var (
+	convertersMu sync.RWMutex
+	converters   = map[reflect.Type]ConverterFunc{}
+)
+
+// Register registers fn as the scan-side conversion into values of type t, so
+// types from packages you do not own, such as decimal.Decimal or uuid.UUID, work with
+// Target, Scanner and ScanStruct. Registered converters take precedence over the built-in
+// conversions. NULL leaves a *t target nil and a t target zero without calling fn.
+// RegisterValuer is the write-side counterpart.
+//
+//	sqlnull.Register(reflect.TypeOf(decimal.Decimal{}), func(src any) (any, error) {
+//		return decimal.NewFromString(fmt.Sprint(src))
+//	})
+func Register(t reflect.Type, fn ConverterFunc) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	converters[t] = fn
+	// converters picked before for t are out of date
+	converterCache.Clear()
+}
+
+// lookupConverter returns the ConverterFunc registered for t.
+func lookupConverter(t reflect.Type) (ConverterFunc, bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	fn, ok := converters[t]
+	return fn, ok
+}
+
+// nullConverted scans through a converter registered with Register.
+type nullConverted struct {
+	typ   reflect.Type
+	fn    ConverterFunc
+	value any
+}
+
+// Scan implements the sql.Scanner interface for nullConverted.
+func (n *nullConverted) Scan(src any) error {
+	n.value = nil
+	if src == nil {
+		return nil
+	}
+	v, err := n.fn(src)
+	if err != nil {
+		return err
+	}
+	val := reflect.ValueOf(v)
+	if !val.IsValid() {
+		return nil
+	}
+	if !val.Type().ConvertibleTo(n.typ) {
+		return fmt.Errorf("converter for %s returned a %s", n.typ, val.Type())
+	}
+	n.value = val.Convert(n.typ).Interface()
+	return nil
+}
+
+// Value implements the driver.Valuer interface for nullConverted.
+func (n *nullConverted) Value() (driver.Value, error) {
+	return n.value, nil
+}
+
+// convertedTarget returns a scanner for target when it points to a value of a type
+// registered with Register, setting the value to zero on NULL.
+func convertedTarget(target any) sql.Scanner {
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return nil
+	}
+	fn, ok := lookupConverter(val.Type().Elem())
+	if !ok {
+		return nil
+	}
+	return scanFunc(func(src any) error {
+		n := &nullConverted{typ: val.Type().Elem(), fn: fn}
+		if err := n.Scan(src); err != nil {
+			return err
+		}
+		if n.value == nil {
+			val.Elem().SetZero()
+			return nil
+		}
+		val.Elem().Set(reflect.ValueOf(n.value))
+		return nil
+	})
+}
@@ -0,0 +1,75 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type converterCents struct {
+	Cents int64
+}
+
+type converterCode string
+
+func init() {
+	sqlnull.Register(reflect.TypeOf(converterCents{}), func(src any) (any, error) {
+		whole, frac, _ := strings.Cut(fmt.Sprint(src), ".")
+		n, err := strconv.ParseInt(whole+(frac + "00")[:2], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return converterCents{n}, nil
+	})
+	// takes precedence over the built-in string conversion
+	sqlnull.Register(reflect.TypeOf(converterCode("")), func(src any) (any, error) {
+		if s, ok := src.(string); ok && s == "" {
+			return nil, errors.New("empty code")
+		}
+		return strings.ToUpper(fmt.Sprint(src)), nil
+	})
+}
+
+func TestRegister(t *testing.T) {
+	db := makeusers(t)
+
+	var price *converterCents
+	var total converterCents
+	var code *converterCode
+	require.NoError(t, db.QueryRow("SELECT '12.5', 3, 'ab'").Scan(sqlnull.Scanner(&price, &total, &code)...))
+	require.Equal(t, &converterCents{1250}, price)
+	require.Equal(t, converterCents{300}, total)
+	require.Equal(t, converterCode("AB"), *code)
+
+	require.NoError(t, db.QueryRow("SELECT NULL, NULL, NULL").Scan(sqlnull.Scanner(&price, &total, &code)...))
+	require.Nil(t, price)
+	require.Equal(t, converterCents{}, total)
+	require.Nil(t, code)
+
+	require.ErrorContains(t, db.QueryRow("SELECT ''").Scan(sqlnull.Target(&code)), "empty code")
+	require.Error(t, db.QueryRow("SELECT 'x'").Scan(sqlnull.Target(&price)))
+
+	type Order struct {
+		ID    int64
+		Price *converterCents `db:"last_name"`
+	}
+	rows, err := db.Query("SELECT id, CASE id WHEN 1 THEN '0.99' END AS last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	var orders []Order
+	require.NoError(t, sqlnull.ScanAll(rows, &orders))
+	require.Equal(t, []Order{{1, &converterCents{99}}, {2, nil}}, orders)
+
+	rows, err = db.Query("SELECT id FROM users ORDER BY id")
+	require.NoError(t, err)
+	var amounts []converterCents
+	require.NoError(t, sqlnull.ScanAll(rows, &amounts))
+	require.Equal(t, []converterCents{{100}, {200}}, amounts)
+}
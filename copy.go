@@ -0,0 +1,63 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CopyOption configures Copy.
+type CopyOption func(*copyConfig)
+
+type copyConfig struct {
+	skipNil bool
+}
+
+// WithSkipNil makes Copy leave a destination field untouched when its source is a nil
+// pointer or an invalid Null, instead of overwriting it with nil. This turns a struct of
+// pointers into a partial update:
+//
+//	var patch struct{ Phone *string }
+//	json.Unmarshal(body, &patch)
+//	err := sqlnull.Copy(&user, patch, sqlnull.WithSkipNil())
+func WithSkipNil() CopyOption {
+	return func(c *copyConfig) {
+		c.skipNil = true
+	}
+}
+
+// Copy copies the fields of the struct src into the matching fields of the struct pointed
+// to by dst, matching by `db` tag or field name and converting values like Convert does.
+// Fields of dst without a matching source field are left untouched.
+func Copy(dst, src any, opts ...CopyOption) error {
+	config := &copyConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	dstVal := reflect.ValueOf(dst)
+	if dstVal.Kind() != reflect.Ptr || dstVal.IsNil() || dstVal.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Copy destination must be a non-nil pointer to struct, got %T", dst)
+	}
+	srcVal := reflect.ValueOf(src)
+	for srcVal.Kind() == reflect.Ptr && !srcVal.IsNil() {
+		srcVal = srcVal.Elem()
+	}
+	if srcVal.Kind() != reflect.Struct {
+		return fmt.Errorf("Copy source must be a struct or a non-nil pointer to struct, got %T", src)
+	}
+
+	return convertStruct(dstVal.Elem(), srcVal, config.skipNil)
+}
+
+// isNil reports whether v is a nil pointer or interface, or an invalid Null wrapper.
+func isNil(v reflect.Value) bool {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	return isNullWrapper(v.Type()) && !v.Field(1).Bool()
+}
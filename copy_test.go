@@ -0,0 +1,60 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type copyUser struct {
+	ID       int64
+	Username string
+	Phone    sql.NullString
+	Email    *string
+}
+
+type copyPatch struct {
+	Username *string
+	Phone    *string
+	Email    sql.NullString
+}
+
+func TestCopy(t *testing.T) {
+	user := copyUser{
+		ID:       1,
+		Username: "johndoe",
+		Phone:    sql.NullString{String: "123456789", Valid: true},
+		Email:    sqlnull.Ptr("john@example.com"),
+	}
+
+	// nil sources overwrite by default
+	patched := user
+	require.NoError(t, sqlnull.Copy(&patched, copyPatch{Username: sqlnull.Ptr("jdoe")}))
+	require.Equal(t, copyUser{ID: 1, Username: "jdoe"}, patched)
+
+	// and are skipped with WithSkipNil
+	patched = user
+	require.NoError(t, sqlnull.Copy(&patched, &copyPatch{Username: sqlnull.Ptr("jdoe")}, sqlnull.WithSkipNil()))
+	require.Equal(t, copyUser{
+		ID:       1,
+		Username: "jdoe",
+		Phone:    sql.NullString{String: "123456789", Valid: true},
+		Email:    sqlnull.Ptr("john@example.com"),
+	}, patched)
+
+	patched = user
+	require.NoError(t, sqlnull.Copy(&patched, copyPatch{Email: sql.NullString{String: "jd@example.com", Valid: true}}, sqlnull.WithSkipNil()))
+	require.Equal(t, "jd@example.com", *patched.Email)
+	require.Equal(t, "johndoe", patched.Username)
+}
+
+func TestCopyErrors(t *testing.T) {
+	var user copyUser
+	require.Error(t, sqlnull.Copy(user, copyPatch{}))
+	require.Error(t, sqlnull.Copy(&user, 42))
+	require.ErrorContains(t, sqlnull.Copy(&user, struct{ Phone bool }{Phone: true}), "field Phone")
+}
@@ -0,0 +1,97 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CopySource feeds structs to pgx's CopyFrom, implementing pgx.CopyFromSource without this
+// package depending on pgx. Rows hold the same columns and args as Insert: nil pointer
+// fields are sent as NULL, and so are zero fields tagged with the zeronull option.
+//
+//	src := sqlnull.CopyFrom(users)
+//	n, err := conn.CopyFrom(ctx, pgx.Identifier{"users"}, src.Columns(), src)
+type CopySource struct {
+	columns []string
+	next    func() (any, bool)
+	current any
+	err     error
+}
+
+// CopyFrom returns a CopySource over items, a slice of structs or pointers to structs.
+func CopyFrom[T any](items []T) *CopySource {
+	i := 0
+	return newCopySource[T](func() (any, bool) {
+		if i >= len(items) {
+			return nil, false
+		}
+		i++
+		return items[i-1], true
+	})
+}
+
+// CopyFromChan returns a CopySource receiving structs or pointers to structs from items until
+// it is closed, for bulk loads that are produced while they are copied.
+func CopyFromChan[T any](items <-chan T) *CopySource {
+	return newCopySource[T](func() (any, bool) {
+		item, ok := <-items
+		return item, ok
+	})
+}
+
+// newCopySource returns a CopySource for the struct type T, reading items with next.
+func newCopySource[T any](next func() (any, bool)) *CopySource {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	s := &CopySource{next: next}
+	if t.Kind() != reflect.Struct {
+		s.err = fmt.Errorf("CopyFrom items must be structs or pointers to structs, got %s", t)
+		return s
+	}
+	for _, field := range insertColumns(t) {
+		s.columns = append(s.columns, field.name)
+	}
+	return s
+}
+
+// Columns returns the column names of the rows, for the columnNames argument of CopyFrom.
+func (s *CopySource) Columns() []string {
+	return s.columns
+}
+
+// Next advances to the next row, reporting false when there are none left or an error occurred.
+func (s *CopySource) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	item, ok := s.next()
+	s.current = item
+	return ok
+}
+
+// Values returns the values of the current row.
+func (s *CopySource) Values() ([]any, error) {
+	val := reflect.ValueOf(s.current)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			s.err = fmt.Errorf("CopyFrom item is a nil %T", s.current)
+			return nil, s.err
+		}
+		val = val.Elem()
+	}
+	_, args, err := Default().insertValues(val)
+	if err != nil {
+		s.err = fmt.Errorf("CopyFrom %w", err)
+		return nil, s.err
+	}
+	return args, nil
+}
+
+// Err returns the error that stopped the copy, if any.
+func (s *CopySource) Err() error {
+	return s.err
+}
@@ -0,0 +1,84 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type copyFromUser struct {
+	ID       int64
+	Username string
+	Phone    *string
+	Age      int    `db:",zeronull"`
+	Full     string `db:"-,derive=FullName"`
+}
+
+func (u copyFromUser) FullName() string {
+	return u.Username
+}
+
+// copyFromSource is pgx.CopyFromSource, declared here to check CopySource satisfies it.
+type copyFromSource interface {
+	Next() bool
+	Values() ([]any, error)
+	Err() error
+}
+
+func collect(t *testing.T, src copyFromSource) [][]any {
+	var rows [][]any
+	for src.Next() {
+		values, err := src.Values()
+		require.NoError(t, err)
+		rows = append(rows, values)
+	}
+	require.NoError(t, src.Err())
+	return rows
+}
+
+func TestCopyFrom(t *testing.T) {
+	users := []copyFromUser{
+		{ID: 1, Username: "johndoe", Phone: sqlnull.Ptr("123456789"), Age: 30},
+		{ID: 2, Username: "jane"},
+	}
+
+	src := sqlnull.CopyFrom(users)
+	require.Equal(t, []string{"id", "username", "phone", "age"}, src.Columns())
+	rows := collect(t, src)
+	require.Len(t, rows, 2)
+	require.Equal(t, int64(1), rows[0][0])
+	require.Equal(t, "123456789", *rows[0][2].(*string))
+	require.Equal(t, 30, rows[0][3])
+	require.Equal(t, []any{int64(2), "jane", nil, nil}, rows[1])
+}
+
+func TestCopyFromChan(t *testing.T) {
+	ch := make(chan *copyFromUser)
+	go func() {
+		defer close(ch)
+		for i := int64(1); i <= 3; i++ {
+			ch <- &copyFromUser{ID: i}
+		}
+	}()
+
+	rows := collect(t, sqlnull.CopyFromChan(ch))
+	require.Len(t, rows, 3)
+	require.Equal(t, int64(3), rows[2][0])
+}
+
+func TestCopyFromErrors(t *testing.T) {
+	src := sqlnull.CopyFrom([]time.Duration{1})
+	require.False(t, src.Next())
+	require.Error(t, src.Err())
+
+	src = sqlnull.CopyFrom([]*copyFromUser{nil})
+	require.True(t, src.Next())
+	_, err := src.Values()
+	require.Error(t, err)
+	require.False(t, src.Next())
+	require.Error(t, src.Err())
+}
@@ -0,0 +1,102 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// DecodeMap fills the struct pointed to by dest from m, matching keys to fields by `db`
+// tag or field name like ScanStruct matches columns. Each value is converted by the same
+// engine as Scan, so nil entries become nil pointers and "42" fills an *int64. Nested
+// map[string]any values fill struct fields, []any values fill slices element by element,
+// and fields without a key are left untouched:
+//
+//	var user User
+//	err := sqlnull.DecodeMap(map[string]any{"id": 1, "phone": nil}, &user)
+func DecodeMap(m map[string]any, dest any) error {
+	return Default().DecodeMap(m, dest)
+}
+
+// DecodeMap fills the struct pointed to by dest from m, converting values like Scan.
+func (c *Config) DecodeMap(m map[string]any, dest any) error {
+	val := reflect.ValueOf(dest)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("DecodeMap destination must be a non-nil pointer to struct, got %T", dest)
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := structFields(val.Elem().Type())
+	for _, key := range keys {
+		for _, field := range fields {
+			if !field.match(key) {
+				continue
+			}
+			target := val.Elem().FieldByIndex(field.index).Addr().Interface()
+			if err := c.decodeValue(target, field, m[key]); err != nil {
+				return fmt.Errorf("key %q: %w", key, err)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// decodeValue stores v into the field pointed to by target.
+func (c *Config) decodeValue(target any, field structField, v any) error {
+	dst := reflect.ValueOf(target).Elem()
+	if sub, ok := v.(map[string]any); ok {
+		if dst.Kind() == reflect.Ptr && dst.Type().Elem().Kind() == reflect.Struct {
+			if dst.IsNil() {
+				dst.Set(reflect.New(dst.Type().Elem()))
+			}
+			return c.DecodeMap(sub, dst.Interface())
+		}
+		if dst.Kind() == reflect.Struct {
+			return c.DecodeMap(sub, target)
+		}
+	}
+
+	if dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() != reflect.Uint8 && dst.Type().Elem().Kind() != reflect.Int32 {
+		items, ok := v.([]any)
+		if !ok && v != nil {
+			// a single value fills a one element slice
+			items, ok = []any{v}, true
+		}
+		if ok {
+			slice := reflect.MakeSlice(dst.Type(), len(items), len(items))
+			for i, item := range items {
+				if err := c.decodeValue(slice.Index(i).Addr().Interface(), structField{}, item); err != nil {
+					return fmt.Errorf("index %d: %w", i, err)
+				}
+			}
+			dst.Set(slice)
+			return nil
+		}
+	}
+
+	scanner := c.fieldTarget(target)
+	if name, ok := field.options["parse"]; ok {
+		var err error
+		if scanner, err = parsedTarget(name, target); err != nil {
+			return err
+		}
+	}
+	if scanner, ok := scanner.(sql.Scanner); ok {
+		return scanner.Scan(v)
+	}
+
+	if v == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	return convertValue(dst, reflect.ValueOf(v))
+}
@@ -0,0 +1,65 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type decodeAddress struct {
+	City *string
+}
+
+type decodeUser struct {
+	ID         int64
+	Username   string
+	Phone      *string
+	Age        *int32
+	Email      sql.NullString
+	VerifiedAt *time.Time
+	Tags       []string
+	Address    *decodeAddress
+}
+
+func TestDecodeMap(t *testing.T) {
+	var m map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": 1,
+		"username": "johndoe",
+		"phone": null,
+		"age": "30",
+		"email": "john@example.com",
+		"verified_at": null,
+		"tags": ["admin"],
+		"address": {"city": "Jakarta"},
+		"unknown": true
+	}`), &m))
+
+	user := decodeUser{Phone: sqlnull.Ptr("123456789")}
+	require.NoError(t, sqlnull.DecodeMap(m, &user))
+	require.Equal(t, decodeUser{
+		ID:       1,
+		Username: "johndoe",
+		Age:      sqlnull.Ptr[int32](30),
+		Email:    sql.NullString{String: "john@example.com", Valid: true},
+		Tags:     []string{"admin"},
+		Address:  &decodeAddress{City: sqlnull.Ptr("Jakarta")},
+	}, user)
+
+	// keys that are absent leave fields untouched
+	require.NoError(t, sqlnull.DecodeMap(map[string]any{"Phone": "555"}, &user))
+	require.Equal(t, "555", *user.Phone)
+	require.Equal(t, "johndoe", user.Username)
+}
+
+func TestDecodeMapErrors(t *testing.T) {
+	var user decodeUser
+	require.Error(t, sqlnull.DecodeMap(nil, user))
+	require.ErrorContains(t, sqlnull.DecodeMap(map[string]any{"age": "thirty"}, &user), `key "age"`)
+}
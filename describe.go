@@ -0,0 +1,95 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Nullability tells how a field represents NULL.
+type Nullability int
+
+const (
+	// NotNullable fields hold a plain value and receive the zero value for NULL.
+	NotNullable Nullability = iota
+	// NullablePointer fields are pointers, nil for NULL.
+	NullablePointer
+	// NullableWrapper fields are sql.Null types or similar wrappers with a Valid flag,
+	// such as Optional and Gob, invalid for NULL.
+	NullableWrapper
+)
+
+// String returns the name of the nullability.
+func (n Nullability) String() string {
+	switch n {
+	case NullablePointer:
+		return "pointer"
+	case NullableWrapper:
+		return "wrapper"
+	}
+	return "value"
+}
+
+// FieldInfo describes a struct field as seen by ScanStruct and Insert.
+type FieldInfo struct {
+	Field       string            // Go field name, dotted for fields of embedded structs
+	Column      string            // column name, empty for fields that are never scanned
+	Type        reflect.Type      // Go type of the field
+	ValueType   reflect.Type      // type of the value held once pointers and wrappers are removed
+	Nullability Nullability       // how the field represents NULL
+	Options     map[string]string // `db` tag options following the column name
+}
+
+// Nullable reports whether the field can hold NULL.
+func (f FieldInfo) Nullable() bool {
+	return f.Nullability != NotNullable
+}
+
+// Describe reports, for each field of the struct v or v points to, its column name, Go type,
+// nullability and tag options, following the rules of ScanStruct. Frameworks and code
+// generators can use it to reason about models without repeating those rules:
+//
+//	for _, field := range sqlnull.Describe(&User{}) {
+//		fmt.Println(field.Column, field.ValueType, field.Nullable())
+//	}
+func Describe(v any) []FieldInfo {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := structFields(t)
+	infos := make([]FieldInfo, len(fields))
+	for i, field := range fields {
+		names := make([]string, len(field.index))
+		for j := range field.index {
+			names[j] = t.FieldByIndex(field.index[:j+1]).Name
+		}
+		fieldType := t.FieldByIndex(field.index).Type
+
+		info := FieldInfo{
+			Field:     strings.Join(names, "."),
+			Column:    field.name,
+			Type:      fieldType,
+			ValueType: fieldType,
+			Options:   field.options,
+		}
+		switch {
+		case fieldType.Kind() == reflect.Ptr:
+			info.Nullability = NullablePointer
+			info.ValueType = fieldType.Elem()
+		case isNullWrapper(fieldType):
+			info.Nullability = NullableWrapper
+			info.ValueType = fieldType.Field(0).Type
+		case fieldType.Implements(optionalType):
+			info.Nullability = NullableWrapper
+			info.ValueType = fieldType.Field(0).Type
+		}
+		infos[i] = info
+	}
+	return infos
+}
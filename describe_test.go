@@ -0,0 +1,60 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type describeBase struct {
+	ID int64
+}
+
+type describeUser struct {
+	describeBase
+	Username   string `db:"login"`
+	Phone      *string
+	Email      sql.NullString `db:"email,redact"`
+	Age        sqlnull.Optional[int]
+	VerifiedAt *time.Time
+	FullName   string `db:"-,derive=MakeFullName"`
+	secret     string
+}
+
+func TestDescribe(t *testing.T) {
+	fields := sqlnull.Describe(&describeUser{})
+	require.Len(t, fields, 7)
+
+	require.Equal(t, "describeBase.ID", fields[0].Field)
+	require.Equal(t, "id", fields[0].Column)
+	require.Equal(t, sqlnull.NotNullable, fields[0].Nullability)
+	require.False(t, fields[0].Nullable())
+
+	require.Equal(t, "login", fields[1].Column)
+	require.Equal(t, reflect.TypeOf(""), fields[1].ValueType)
+
+	require.Equal(t, sqlnull.NullablePointer, fields[2].Nullability)
+	require.Equal(t, reflect.TypeOf(""), fields[2].ValueType)
+	require.Equal(t, "pointer", fields[2].Nullability.String())
+
+	require.Equal(t, sqlnull.NullableWrapper, fields[3].Nullability)
+	require.Equal(t, reflect.TypeOf(""), fields[3].ValueType)
+	require.Contains(t, fields[3].Options, "redact")
+
+	require.Equal(t, sqlnull.NullableWrapper, fields[4].Nullability)
+	require.Equal(t, reflect.TypeOf(0), fields[4].ValueType)
+
+	require.Equal(t, reflect.TypeOf(time.Time{}), fields[5].ValueType)
+	require.True(t, fields[5].Nullable())
+
+	require.Equal(t, "", fields[6].Column)
+	require.Equal(t, "MakeFullName", fields[6].Options["derive"])
+
+	require.Nil(t, sqlnull.Describe(42))
+}
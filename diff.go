@@ -0,0 +1,291 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+// DiffKind tells how a Difference came about.
+type DiffKind int
+
+const (
+	DiffChanged DiffKind = iota // the value differs on both sides
+	DiffAdded                   // the row or column exists on the right side only
+	DiffRemoved                 // the row or column exists on the left side only
+)
+
+// Difference describes a single difference between two result sets.
+type Difference struct {
+	Kind   DiffKind
+	Row    int    // row index, -1 for a column present on one side only
+	Column string // column name, empty for a row present on one side only
+	Left   any    // left value in normalized form with nil for NULL, or the whole row for DiffRemoved
+	Right  any    // right value in normalized form with nil for NULL, or the whole row for DiffAdded
+}
+
+// String returns a readable description of d.
+func (d Difference) String() string {
+	switch {
+	case d.Row < 0 && d.Kind == DiffAdded:
+		return fmt.Sprintf("column %q: only in right", d.Column)
+	case d.Row < 0:
+		return fmt.Sprintf("column %q: only in left", d.Column)
+	case d.Kind == DiffAdded:
+		return fmt.Sprintf("row %d: only in right %v", d.Row, d.Right)
+	case d.Kind == DiffRemoved:
+		return fmt.Sprintf("row %d: only in left %v", d.Row, d.Left)
+	}
+	return fmt.Sprintf("row %d column %q: %s != %s", d.Row, d.Column, diffLiteral(d.Left), diffLiteral(d.Right))
+}
+
+// diffLiteral renders a normalized value for Difference.String.
+func diffLiteral(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprint(v)
+}
+
+// DiffOption configures Diff and DiffRows.
+type DiffOption func(*diffConfig)
+
+type diffConfig struct {
+	emptyAsNull bool
+}
+
+// WithEmptyAsNull makes Diff and DiffRows treat empty strings and byte slices as NULL,
+// for drivers such as Oracle's that do not tell them apart.
+func WithEmptyAsNull() DiffOption {
+	return func(c *diffConfig) {
+		c.emptyAsNull = true
+	}
+}
+
+// DiffRows reads the remaining rows of left and right with Matrix and reports their
+// differences, e.g. to validate a migration between two databases. Rows are compared by
+// position and columns by name, and values are normalized before comparison:
+//
+//   - NULL is NULL however the driver returns it, and differs from "" and 0.
+//   - []byte and string holding the same text are equal.
+//   - Numbers compare by value whatever their type, so int32(1), int64(1) and 1.0 are equal.
+//   - Times compare as instants, whatever their location.
+//
+// Both rows are closed on return.
+//
+//	diffs, err := sqlnull.DiffRows(oldRows, newRows)
+//	for _, d := range diffs {
+//		log.Println(d)
+//	}
+func DiffRows(left, right *sql.Rows, opts ...DiffOption) ([]Difference, error) {
+	defer right.Close()
+
+	leftColumns, leftData, err := Matrix(left)
+	if err != nil {
+		return nil, fmt.Errorf("DiffRows left: %w", err)
+	}
+	rightColumns, rightData, err := Matrix(right)
+	if err != nil {
+		return nil, fmt.Errorf("DiffRows right: %w", err)
+	}
+	return diffTables(leftColumns, leftData, rightColumns, rightData, opts), nil
+}
+
+// Diff reports the differences between two slices of structs, such as the results of
+// ScanStruct against two databases, matching fields to columns by `db` tag or field name.
+// The two slices may hold different struct types, so a sql.NullString field on one side
+// compares against a *string field on the other. Values are normalized like DiffRows does.
+func Diff(left, right any, opts ...DiffOption) ([]Difference, error) {
+	leftColumns, leftData, err := diffStructs(left)
+	if err != nil {
+		return nil, fmt.Errorf("Diff left: %w", err)
+	}
+	rightColumns, rightData, err := diffStructs(right)
+	if err != nil {
+		return nil, fmt.Errorf("Diff right: %w", err)
+	}
+	return diffTables(leftColumns, leftData, rightColumns, rightData, opts), nil
+}
+
+// diffStructs returns the columns and rows of a slice of structs or struct pointers.
+func diffStructs(v any) ([]string, [][]any, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return nil, nil, fmt.Errorf("value must be a slice of structs, got %T", v)
+	}
+	elemType := val.Type().Elem()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("value must be a slice of structs, got %T", v)
+	}
+
+	var columns []string
+	var fields []structField
+	for _, field := range structFields(elemType) {
+		if field.name != "" {
+			columns = append(columns, field.name)
+			fields = append(fields, field)
+		}
+	}
+
+	data := make([][]any, val.Len())
+	for i := range data {
+		elem := val.Index(i)
+		for elem.Kind() == reflect.Ptr && !elem.IsNil() {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			return nil, nil, fmt.Errorf("index %d is a nil %s", i, elem.Type())
+		}
+		row := make([]any, len(fields))
+		for j, field := range fields {
+			row[j] = elem.FieldByIndex(field.index).Interface()
+		}
+		data[i] = row
+	}
+	return columns, data, nil
+}
+
+// diffTables compares two tables row by row, matching their columns by name.
+func diffTables(leftColumns []string, leftData [][]any, rightColumns []string, rightData [][]any, opts []DiffOption) []Difference {
+	config := &diffConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	var diffs []Difference
+	var pairs [][2]int
+	for i, column := range leftColumns {
+		j := columnIndex(rightColumns, column)
+		if j < 0 {
+			diffs = append(diffs, Difference{Kind: DiffRemoved, Row: -1, Column: column})
+			continue
+		}
+		pairs = append(pairs, [2]int{i, j})
+	}
+	for _, column := range rightColumns {
+		if columnIndex(leftColumns, column) < 0 {
+			diffs = append(diffs, Difference{Kind: DiffAdded, Row: -1, Column: column})
+		}
+	}
+
+	normalizeRow := func(row []any) []any {
+		normalized := make([]any, len(row))
+		for i, v := range row {
+			normalized[i] = config.normalize(reflect.ValueOf(v))
+		}
+		return normalized
+	}
+
+	for row := 0; row < max(len(leftData), len(rightData)); row++ {
+		switch {
+		case row >= len(rightData):
+			diffs = append(diffs, Difference{Kind: DiffRemoved, Row: row, Left: normalizeRow(leftData[row])})
+			continue
+		case row >= len(leftData):
+			diffs = append(diffs, Difference{Kind: DiffAdded, Row: row, Right: normalizeRow(rightData[row])})
+			continue
+		}
+		for _, pair := range pairs {
+			left := config.normalize(reflect.ValueOf(leftData[row][pair[0]]))
+			right := config.normalize(reflect.ValueOf(rightData[row][pair[1]]))
+			if !reflect.DeepEqual(left, right) {
+				diffs = append(diffs, Difference{Kind: DiffChanged, Row: row, Column: leftColumns[pair[0]], Left: left, Right: right})
+			}
+		}
+	}
+	return diffs
+}
+
+// columnIndex returns the position of column within columns, or -1.
+func columnIndex(columns []string, column string) int {
+	for i, c := range columns {
+		if c == column {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalize returns v in the form compared by Diff: nil for NULL, int64, uint64 or float64
+// for numbers, string for text and bytes, and a UTC time.Time for times.
+func (c *diffConfig) normalize(v reflect.Value) any {
+	for {
+		if !v.IsValid() || isNil(v) {
+			return nil
+		}
+		switch {
+		case v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface:
+			v = v.Elem()
+			continue
+		case isNullWrapper(v.Type()):
+			v = v.Field(0)
+			continue
+		case v.Type().Implements(optionalType):
+			value, valid := v.Interface().(optional).optional()
+			if !valid {
+				return nil
+			}
+			v = reflect.ValueOf(value)
+			continue
+		}
+		break
+	}
+
+	switch v.Type() {
+	case timeType:
+		return v.Interface().(time.Time).UTC().Round(0)
+	case ratType:
+		r := new(big.Rat)
+		reflect.ValueOf(r).Elem().Set(v)
+		if r.IsInt() && r.Num().IsInt64() {
+			return r.Num().Int64()
+		}
+		f, _ := r.Float64()
+		return f
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() <= math.MaxInt64 {
+			return int64(v.Uint())
+		}
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f)
+		}
+		return f
+	case reflect.String:
+		if c.emptyAsNull && v.Len() == 0 {
+			return nil
+		}
+		return v.String()
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if c.emptyAsNull && v.Len() == 0 {
+				return nil
+			}
+			return string(v.Bytes())
+		}
+	}
+	return v.Interface()
+}
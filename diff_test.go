@@ -0,0 +1,69 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffRows(t *testing.T) {
+	left, right := makeusers(t), makeusers(t)
+	_, err := right.Exec(`
+		ALTER TABLE users ADD COLUMN phone TEXT;
+		UPDATE users SET last_name = '' WHERE id = 2;
+		INSERT INTO users (id, first_name) VALUES (3, 'joe');
+	`)
+	require.NoError(t, err)
+
+	query := func(db *sql.DB) *sql.Rows {
+		rows, err := db.Query("SELECT * FROM users ORDER BY id")
+		require.NoError(t, err)
+		return rows
+	}
+
+	diffs, err := sqlnull.DiffRows(query(left), query(right))
+	require.NoError(t, err)
+	require.Equal(t, []sqlnull.Difference{
+		{Kind: sqlnull.DiffAdded, Row: -1, Column: "phone"},
+		{Kind: sqlnull.DiffChanged, Row: 1, Column: "last_name", Left: nil, Right: ""},
+		{Kind: sqlnull.DiffAdded, Row: 2, Right: []any{int64(3), "joe", nil, nil, nil}},
+	}, diffs)
+	require.Equal(t, `column "phone": only in right`, diffs[0].String())
+	require.Equal(t, `row 1 column "last_name": NULL != ""`, diffs[1].String())
+
+	diffs, err = sqlnull.DiffRows(query(left), query(right), sqlnull.WithEmptyAsNull())
+	require.NoError(t, err)
+	require.Len(t, diffs, 2)
+}
+
+func TestDiff(t *testing.T) {
+	type Old struct {
+		ID        int32
+		Phone     sql.NullString
+		CreatedAt time.Time
+	}
+	type New struct {
+		ID        int64   `db:"id"`
+		Phone     *string `db:"phone"`
+		CreatedAt sqlnull.Optional[time.Time]
+	}
+	at := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	diffs, err := sqlnull.Diff(
+		[]Old{{ID: 1, CreatedAt: at}, {ID: 2, Phone: sql.NullString{String: "555", Valid: true}, CreatedAt: at}},
+		[]*New{{ID: 1, CreatedAt: sqlnull.Some(at.In(time.FixedZone("CEST", 7200)))}, {ID: 2, Phone: sqlnull.Ptr("556")}},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []sqlnull.Difference{
+		{Kind: sqlnull.DiffChanged, Row: 1, Column: "phone", Left: "555", Right: "556"},
+		{Kind: sqlnull.DiffChanged, Row: 1, Column: "created_at", Left: at, Right: nil},
+	}, diffs)
+
+	_, err = sqlnull.Diff(42, []New{})
+	require.Error(t, err)
+}
@@ -0,0 +1,103 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// WithDurationUnit sets the unit of integer and floating point values scanned into
+// time.Duration targets, e.g. time.Second for columns holding seconds. Without it
+// numbers are read as nanoseconds. Text such as "1h30m" is parsed with time.ParseDuration
+// whatever the unit.
+func WithDurationUnit(unit time.Duration) Option {
+	return func(c *Config) {
+		c.durationUnit = unit
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// scaleDuration converts a number in the duration unit of c to nanoseconds, leaving other
+// values untouched.
+func (c *Config) scaleDuration(src any) any {
+	if c.durationUnit <= 0 || c.durationUnit == time.Nanosecond {
+		return src
+	}
+	if s, ok := text(src); ok {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			src = n
+		} else if f, err := strconv.ParseFloat(s, 64); err == nil {
+			src = f
+		}
+	}
+	switch v := src.(type) {
+	case int64:
+		return v * int64(c.durationUnit)
+	case float64:
+		return v * float64(c.durationUnit)
+	}
+	return src
+}
+
+// durationTarget returns a scanner for a *time.Duration target, which database/sql can
+// only fill from integers, setting the duration to zero on NULL.
+func (c *Config) durationTarget(target *time.Duration) sql.Scanner {
+	return scanFunc(func(src any) error {
+		var d *time.Duration
+		if err := c.New(&d).Scan(src); err != nil {
+			return err
+		}
+		if d == nil {
+			*target = 0
+			return nil
+		}
+		*target = *d
+		return nil
+	})
+}
+
+// nullDuration scans integer nanoseconds, floating point nanoseconds, and text either
+// holding an integer or parsed by time.ParseDuration.
+type nullDuration struct {
+	value any
+}
+
+// Scan implements the sql.Scanner interface for nullDuration.
+func (n *nullDuration) Scan(src any) error {
+	if s, ok := text(src); ok {
+		if d, err := strconv.ParseInt(s, 10, 64); err == nil {
+			src = d
+		} else if d, err := time.ParseDuration(s); err == nil {
+			src = int64(d)
+		} else {
+			return fmt.Errorf("invalid duration %q", s)
+		}
+	}
+
+	switch v := src.(type) {
+	case nil:
+		n.value = nil
+	case int64:
+		n.value = time.Duration(v)
+	case float64:
+		if math.IsNaN(v) || math.Abs(v) > math.MaxInt64 {
+			return fmt.Errorf("duration %v out of range", v)
+		}
+		n.value = time.Duration(math.Round(v))
+	default:
+		return fmt.Errorf("cannot convert %T value to time.Duration", src)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface for nullDuration.
+func (n *nullDuration) Value() (driver.Value, error) {
+	return n.value, nil
+}
@@ -0,0 +1,78 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuration(t *testing.T) {
+	var d *time.Duration
+	require.NoError(t, sqlnull.New(&d).Scan(int64(1500)))
+	require.Equal(t, 1500*time.Nanosecond, *d)
+
+	require.NoError(t, sqlnull.New(&d).Scan("1h30m"))
+	require.Equal(t, 90*time.Minute, *d)
+
+	require.NoError(t, sqlnull.New(&d).Scan([]byte("42")))
+	require.Equal(t, 42*time.Nanosecond, *d)
+
+	require.NoError(t, sqlnull.New(&d).Scan(nil))
+	require.Nil(t, d)
+
+	require.ErrorContains(t, sqlnull.New(&d).Scan("soon"), `invalid duration "soon"`)
+
+	seconds := sqlnull.NewConfig(sqlnull.WithDurationUnit(time.Second))
+	require.NoError(t, seconds.New(&d).Scan(int64(90)))
+	require.Equal(t, 90*time.Second, *d)
+	require.NoError(t, seconds.New(&d).Scan(1.5))
+	require.Equal(t, 1500*time.Millisecond, *d)
+	require.NoError(t, seconds.New(&d).Scan("2m"))
+	require.Equal(t, 2*time.Minute, *d)
+}
+
+func TestDurationPlain(t *testing.T) {
+	db := makeusers(t)
+
+	var d time.Duration = time.Hour
+	require.NoError(t, db.QueryRow("SELECT '1m30s'").Scan(sqlnull.Target(&d)))
+	require.Equal(t, 90*time.Second, d)
+
+	require.NoError(t, db.QueryRow("SELECT NULL").Scan(sqlnull.Target(&d)))
+	require.Zero(t, d)
+
+	type Job struct {
+		ID      int64         `db:"id"`
+		Timeout time.Duration `db:"timeout"`
+	}
+	rows, err := db.Query("SELECT id, id * 30 AS timeout FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+	var job Job
+	require.NoError(t, sqlnull.NewConfig(sqlnull.WithDurationUnit(time.Second)).ScanStruct(rows, &job))
+	require.Equal(t, 30*time.Second, job.Timeout)
+}
+
+func TestScannerEDuration(t *testing.T) {
+	db := makeusers(t)
+
+	var d time.Duration
+	targets, err := sqlnull.ScannerE(&d)
+	require.NoError(t, err)
+	require.NoError(t, db.QueryRow("SELECT '1h'").Scan(targets...))
+	require.Equal(t, time.Hour, d)
+
+	var at time.Time
+	config := sqlnull.NewConfig(sqlnull.WithTimeLayouts(time.DateTime), sqlnull.WithZeroDatesAsNull())
+	targets, err = config.ScannerE(&at)
+	require.NoError(t, err)
+	require.NoError(t, db.QueryRow("SELECT '2024-05-01 10:30:15'").Scan(targets...))
+	require.Equal(t, time.Date(2024, 5, 1, 10, 30, 15, 0, time.UTC), at)
+	require.NoError(t, db.QueryRow("SELECT '0000-00-00 00:00:00'").Scan(targets...))
+	require.True(t, at.IsZero())
+}
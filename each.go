@@ -0,0 +1,45 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"context"
+	"database/sql"
+)
+
+// EachCtx scans every row of rows into targets, wrapped like Scanner, and calls fn after each row.
+// ctx is checked between rows, so long exports can be cancelled without draining the
+// whole result set. Iteration stops at the first error returned by fn, and rows is
+// always closed on return. The Config attached to ctx by WithConfig is used, if any.
+//
+//	var id int64
+//	var phone *string
+//	err = sqlnull.EachCtx(ctx, rows, []any{&id, &phone}, func() error {
+//		return export(id, phone)
+//	})
+func EachCtx(ctx context.Context, rows *sql.Rows, targets []any, fn func() error) error {
+	return ConfigFromContext(ctx).EachCtx(ctx, rows, targets, fn)
+}
+
+// EachCtx scans every row of rows into targets, wrapped like Scanner, and calls fn after each row.
+func (c *Config) EachCtx(ctx context.Context, rows *sql.Rows, targets []any, fn func() error) error {
+	defer rows.Close()
+
+	scanners := c.Scanner(targets...)
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.reset(targets...)
+		if err := c.scanRow(rows.Scan, scanners, nil, nil); err != nil {
+			return err
+		}
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return rows.Close()
+}
@@ -0,0 +1,52 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEachCtx(t *testing.T) {
+	db := makeusers(t)
+
+	rows, err := db.Query("SELECT id, last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+
+	var id int64
+	var lastName *string
+	var got []any
+	err = sqlnull.EachCtx(context.Background(), rows, []any{&id, &lastName}, func() error {
+		got = append(got, id, lastName)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+	require.Equal(t, "doe", *got[1].(*string))
+	require.Nil(t, got[3])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rows, err = db.Query("SELECT id, last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+
+	var calls int
+	err = sqlnull.EachCtx(ctx, rows, []any{&id, &lastName}, func() error {
+		calls++
+		cancel()
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, calls)
+
+	stop := errors.New("stop")
+	rows, err = db.Query("SELECT id, last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	err = sqlnull.EachCtx(context.Background(), rows, []any{&id, &lastName}, func() error {
+		return stop
+	})
+	require.ErrorIs(t, err, stop)
+}
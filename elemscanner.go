@@ -0,0 +1,36 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+)
+
+// nullScanner scans pointer targets to types implementing sql.Scanner themselves, such as
+// **uuid.UUID or **KSUID: NULL leaves the pointer nil, any other value is scanned by the
+// Scan method of a newly allocated element.
+type nullScanner struct {
+	typ   reflect.Type
+	value any
+}
+
+// Scan implements the sql.Scanner interface for nullScanner.
+func (n *nullScanner) Scan(src any) error {
+	if src == nil {
+		n.value = nil
+		return nil
+	}
+	ptr := reflect.New(n.typ)
+	if err := ptr.Interface().(sql.Scanner).Scan(src); err != nil {
+		return err
+	}
+	n.value = ptr.Elem().Interface()
+	return nil
+}
+
+// Value implements the driver.Valuer interface for nullScanner.
+func (n *nullScanner) Value() (driver.Value, error) {
+	return n.value, nil
+}
@@ -0,0 +1,50 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+// upperName implements sql.Scanner on its pointer, storing text upper-cased.
+type upperName struct {
+	Name string
+}
+
+func (u *upperName) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		u.Name = strings.ToUpper(v)
+	case []byte:
+		u.Name = strings.ToUpper(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into upperName", src)
+	}
+	return nil
+}
+
+func TestElemScanner(t *testing.T) {
+	db := makeusers(t)
+
+	var first, last *upperName
+	err := db.QueryRow("SELECT first_name, last_name FROM users WHERE id=2").Scan(sqlnull.Scanner(&first, &last)...)
+	require.NoError(t, err)
+	require.Equal(t, &upperName{"JANE"}, first)
+	require.Nil(t, last)
+
+	require.Error(t, db.QueryRow("SELECT 1").Scan(sqlnull.Target(&first)))
+
+	require.NoError(t, sqlnull.ConvertAssign(&first, "bob"))
+	require.Equal(t, &upperName{"BOB"}, first)
+	require.NoError(t, sqlnull.ConvertAssign(&first, nil))
+	require.Nil(t, first)
+
+	var n sqlnull.Null[*upperName]
+	require.NoError(t, n.Scan("ann"))
+	require.Equal(t, sqlnull.Null[*upperName]{V: &upperName{"ANN"}, Valid: true}, n)
+}
@@ -0,0 +1,228 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	membersMu sync.RWMutex
+	members   = map[reflect.Type][]string{}
+)
+
+// RegisterEnum registers the members of the string type T, so Enum[T] rejects any other
+// value when scanning or writing it, as a MySQL ENUM column would.
+//
+//	type Status string
+//
+//	sqlnull.RegisterEnum[Status]("active", "suspended", "deleted")
+func RegisterEnum[T ~string](values ...T) {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = string(v)
+	}
+	registerMembers(reflect.TypeOf((*T)(nil)).Elem(), names)
+}
+
+// RegisterSet registers the members of a MySQL SET column for the bitmask type T, bit i
+// standing for names[i] as in MySQL's own numeric form of SET values. Fields of type T, or
+// *T, tagged with the set option are then scanned from the comma separated SET text,
+// rejecting unknown members, and T is written back as that text.
+//
+//	type Permission uint8
+//
+//	sqlnull.RegisterSet[Permission]("read", "write", "admin")
+func RegisterSet[T ~uint8 | ~uint16 | ~uint32 | ~uint64](names ...string) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if len(names) > t.Bits() {
+		panic(fmt.Sprintf("sqlnull: RegisterSet: %d members do not fit in %s", len(names), t))
+	}
+	registerMembers(t, names)
+}
+
+func registerMembers(t reflect.Type, names []string) {
+	membersMu.Lock()
+	defer membersMu.Unlock()
+	members[t] = slices.Clone(names)
+}
+
+// lookupMembers returns the members registered for t.
+func lookupMembers(t reflect.Type) ([]string, bool) {
+	membersMu.RLock()
+	defer membersMu.RUnlock()
+	names, ok := members[t]
+	return names, ok
+}
+
+// Enum holds the value of an ENUM column as the string type T. It implements sql.Scanner
+// and driver.Valuer; NULL leaves Valid false, and an invalid Enum is written as NULL.
+// When members were registered for T with RegisterEnum, any other value fails.
+type Enum[T ~string] struct {
+	V     T
+	Valid bool
+}
+
+// Scan implements the sql.Scanner interface for Enum.
+func (e *Enum[T]) Scan(src any) error {
+	e.V, e.Valid = "", false
+
+	var s string
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T value into %T", src, e.V)
+	}
+	if err := checkEnum(T(s)); err != nil {
+		return err
+	}
+	e.V, e.Valid = T(s), true
+	return nil
+}
+
+// Value implements the driver.Valuer interface for Enum.
+func (e Enum[T]) Value() (driver.Value, error) {
+	if !e.Valid {
+		return nil, nil
+	}
+	if err := checkEnum(e.V); err != nil {
+		return nil, err
+	}
+	return string(e.V), nil
+}
+
+// checkEnum fails when members are registered for T and v is not one of them.
+func checkEnum[T ~string](v T) error {
+	names, ok := lookupMembers(reflect.TypeOf(v))
+	if ok && !slices.Contains(names, string(v)) {
+		return fmt.Errorf("unknown %T member %q", v, string(v))
+	}
+	return nil
+}
+
+// SetOf returns a scanner filling dst from a MySQL SET column, which the driver returns
+// as comma separated members. dst points to a []string, to a bitmask type registered with
+// RegisterSet, or to a pointer to either. NULL stores a nil slice, zero or a nil pointer.
+// ScanStruct does the same for fields tagged with the set option: `db:"perms,set"`.
+//
+//	var tags []string
+//	err = row.Scan(&id, sqlnull.SetOf(&tags))
+func SetOf(dst any) sql.Scanner {
+	return scanFunc(func(src any) error {
+		val := reflect.ValueOf(dst)
+		if val.Kind() != reflect.Ptr || val.IsNil() {
+			return fmt.Errorf("SetOf destination must be a non-nil pointer, got %T", dst)
+		}
+		return scanSet(val.Elem(), src)
+	})
+}
+
+// scanSet stores the SET value src into dst.
+func scanSet(dst reflect.Value, src any) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr {
+		ptr := reflect.New(dst.Type().Elem())
+		if err := scanSet(ptr.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	}
+
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int64:
+		names, ok := lookupMembers(dst.Type())
+		if !ok || v < 0 || (len(names) < 64 && v >= 1<<len(names)) {
+			return fmt.Errorf("cannot scan SET value %d into %s", v, dst.Type())
+		}
+		dst.SetUint(uint64(v))
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T value into %s", src, dst.Type())
+	}
+
+	var items []string
+	if s != "" {
+		items = strings.Split(s, ",")
+	}
+
+	if dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.String {
+		slice := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			slice.Index(i).SetString(item)
+		}
+		dst.Set(slice)
+		return nil
+	}
+
+	names, ok := lookupMembers(dst.Type())
+	if !ok || !isUnsigned(dst.Kind()) {
+		return fmt.Errorf("SET column needs a []string or a type registered with RegisterSet, got %s", dst.Type())
+	}
+	var mask uint64
+	for _, item := range items {
+		i := slices.Index(names, item)
+		if i < 0 {
+			return fmt.Errorf("unknown %s member %q", dst.Type(), item)
+		}
+		mask |= 1 << i
+	}
+	dst.SetUint(mask)
+	return nil
+}
+
+// setText renders val, a []string or a bitmask type registered with RegisterSet, as the
+// comma separated text of a SET value.
+func setText(val reflect.Value) (string, error) {
+	if val.Kind() == reflect.Slice && val.Type().Elem().Kind() == reflect.String {
+		items := make([]string, val.Len())
+		for i := range items {
+			items[i] = val.Index(i).String()
+		}
+		return strings.Join(items, ","), nil
+	}
+
+	names, ok := lookupMembers(val.Type())
+	if !ok || !isUnsigned(val.Kind()) {
+		return "", fmt.Errorf("SET value must be a []string or a type registered with RegisterSet, got %s", val.Type())
+	}
+	var items []string
+	for i, mask := 0, val.Uint(); mask != 0; i, mask = i+1, mask>>1 {
+		if mask&1 == 0 {
+			continue
+		}
+		if i >= len(names) {
+			return "", fmt.Errorf("%s value %d has bits beyond its %d members", val.Type(), val.Uint(), len(names))
+		}
+		items = append(items, names[i])
+	}
+	return strings.Join(items, ","), nil
+}
+
+// isUnsigned reports whether k is an unsigned integer kind.
+func isUnsigned(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type enumStatus string
+
+type setPermission uint8
+
+func init() {
+	sqlnull.RegisterEnum[enumStatus]("active", "suspended")
+	sqlnull.RegisterSet[setPermission]("read", "write", "admin")
+}
+
+func TestEnum(t *testing.T) {
+	db := makeusers(t)
+
+	var status sqlnull.Enum[enumStatus]
+	require.NoError(t, db.QueryRow("SELECT 'active'").Scan(&status))
+	require.Equal(t, sqlnull.Enum[enumStatus]{V: "active", Valid: true}, status)
+	require.NoError(t, db.QueryRow("SELECT NULL").Scan(&status))
+	require.False(t, status.Valid)
+	require.ErrorContains(t, db.QueryRow("SELECT 'gone'").Scan(&status), `unknown sqlnull_test.enumStatus member "gone"`)
+
+	v, err := sqlnull.Enum[enumStatus]{V: "suspended", Valid: true}.Value()
+	require.NoError(t, err)
+	require.Equal(t, "suspended", v)
+	_, err = sqlnull.Enum[enumStatus]{V: "gone", Valid: true}.Value()
+	require.Error(t, err)
+
+	// types without registered members accept any value
+	var free sqlnull.Enum[string]
+	require.NoError(t, db.QueryRow("SELECT 'anything'").Scan(&free))
+	require.Equal(t, "anything", free.V)
+}
+
+func TestSet(t *testing.T) {
+	db := makeusers(t)
+
+	var tags []string
+	require.NoError(t, db.QueryRow("SELECT 'a,b'").Scan(sqlnull.SetOf(&tags)))
+	require.Equal(t, []string{"a", "b"}, tags)
+	require.NoError(t, db.QueryRow("SELECT ''").Scan(sqlnull.SetOf(&tags)))
+	require.Empty(t, tags)
+
+	var perm *setPermission
+	require.NoError(t, db.QueryRow("SELECT 'read,admin'").Scan(sqlnull.SetOf(&perm)))
+	require.Equal(t, setPermission(0b101), *perm)
+	require.NoError(t, db.QueryRow("SELECT NULL").Scan(sqlnull.SetOf(&perm)))
+	require.Nil(t, perm)
+	require.NoError(t, db.QueryRow("SELECT 3").Scan(sqlnull.SetOf(&perm)))
+	require.Equal(t, setPermission(0b011), *perm)
+	require.ErrorContains(t, db.QueryRow("SELECT 'read,root'").Scan(sqlnull.SetOf(&perm)), `unknown sqlnull_test.setPermission member "root"`)
+	require.Error(t, db.QueryRow("SELECT 8").Scan(sqlnull.SetOf(&perm)))
+
+	type Account struct {
+		ID    int64
+		Tags  []string      `db:"tags,set"`
+		Perms setPermission `db:"perms,set"`
+	}
+	query, args, err := sqlnull.Insert("accounts", &Account{ID: 1, Tags: []string{"x", "y"}, Perms: 0b110})
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO accounts (id, tags, perms) VALUES (?, ?, ?)", query)
+	require.Equal(t, []any{int64(1), "x,y", "write,admin"}, args)
+
+	_, err = db.Exec("CREATE TABLE accounts (id INTEGER, tags TEXT, perms TEXT)")
+	require.NoError(t, err)
+	_, err = db.Exec(query, args...)
+	require.NoError(t, err)
+
+	rows, err := db.Query("SELECT id, tags, perms FROM accounts")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+	var account Account
+	require.NoError(t, sqlnull.ScanStruct(rows, &account))
+	require.Equal(t, Account{ID: 1, Tags: []string{"x", "y"}, Perms: 0b110}, account)
+}
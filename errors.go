@@ -0,0 +1,105 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ColumnError reports the conversion failure of a single column.
+type ColumnError struct {
+	Index  int    // column position within the scan
+	Column string // column name, when known
+	Err    error
+}
+
+// Error implements the error interface for ColumnError.
+func (e *ColumnError) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf("column #%d %q: %v", e.Index, e.Column, e.Err)
+	}
+	return fmt.Sprintf("column #%d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying conversion error.
+func (e *ColumnError) Unwrap() error {
+	return e.Err
+}
+
+// WithAllErrors makes Scan and ScanStruct keep going when a column fails to convert.
+// Every column is scanned and the returned error joins a *ColumnError for each failing
+// column, instead of stopping at the first one. Targets handed to database/sql untouched
+// (see Target) still abort the scan on error.
+func WithAllErrors() Option {
+	return func(c *Config) {
+		c.allErrors = true
+	}
+}
+
+// Scan wraps targets like Scanner and scans them with scan, typically row.Scan or rows.Scan:
+//
+//	err = sqlnull.Scan(row.Scan, &cust.ID, &cust.Username, &cust.Phone, &cust.VerifiedAt)
+func Scan(scan func(dest ...any) error, targets ...any) error {
+	return Default().Scan(scan, targets...)
+}
+
+// Scan wraps targets like Scanner and scans them with scan, typically row.Scan or rows.Scan.
+func (c *Config) Scan(scan func(dest ...any) error, targets ...any) error {
+	c.reset(targets...)
+	return c.scanRow(scan, c.Scanner(targets...), nil, nil)
+}
+
+// scanRow calls scan with the already wrapped targets. With WithAllErrors, or when
+// a report is requested, the conversion errors are collected per column instead of
+// aborting the scan.
+func (c *Config) scanRow(scan func(dest ...any) error, targets []any, columns []string, report *Report) error {
+	if !c.allErrors && report == nil {
+		return scan(targets...)
+	}
+
+	results := make(Report, len(targets))
+	var errs []error
+	collected := make([]any, len(targets))
+	for i, target := range targets {
+		result := &results[i]
+		result.Index = i
+		if i < len(columns) {
+			result.Column = columns[i]
+		}
+
+		scanner, ok := target.(sql.Scanner)
+		if !ok {
+			collected[i] = target
+			continue
+		}
+		collected[i] = scanFunc(func(src any) error {
+			_, result.Truncated, _ = c.limit(src)
+			if err := scanner.Scan(src); err != nil {
+				result.Status, result.Err = ColumnFailed, err
+				errs = append(errs, &ColumnError{Index: result.Index, Column: result.Column, Err: err})
+			} else if src == nil {
+				result.Status = ColumnNull
+			} else {
+				result.Status = ColumnAssigned
+			}
+			return nil
+		})
+	}
+
+	err := scan(collected...)
+	if err == nil {
+		// targets left to database/sql were assigned along with the rest
+		for i := range results {
+			if results[i].Status == ColumnNotScanned {
+				results[i].Status = ColumnAssigned
+			}
+		}
+		err = errors.Join(errs...)
+	}
+	if report != nil {
+		*report = results
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan(t *testing.T) {
+	row, err := makedatabase(true, 99, 88.89, time.Now(), "lorem ipsum")
+	require.NoError(t, err)
+
+	var test NewSqlNullTest
+	err = sqlnull.Scan(row.Scan,
+		&test.FieldBool,
+		&test.FieldByte,
+		&test.FieldFloat,
+		&test.FieldInt16,
+		&test.FieldInt32,
+		&test.FieldInt64,
+		&test.FieldString,
+		&test.FieldTime,
+		nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, int64(99), *test.FieldInt64)
+}
+
+func TestAllErrors(t *testing.T) {
+	row, err := makedatabase(true, 99, 88.89, time.Now(), "lorem ipsum")
+	require.NoError(t, err)
+
+	var test NewSqlNullTest
+	var badString, badTime *int64
+	config := sqlnull.NewConfig(sqlnull.WithAllErrors())
+	err = config.Scan(row.Scan,
+		&test.FieldBool,
+		&test.FieldByte,
+		&test.FieldFloat,
+		&test.FieldInt16,
+		&test.FieldInt32,
+		&test.FieldInt64,
+		&badString,
+		&badTime,
+		&test.FieldBool,
+	)
+	require.Error(t, err)
+	require.Equal(t, int64(99), *test.FieldInt64)
+
+	var columnErr *sqlnull.ColumnError
+	require.True(t, errors.As(err, &columnErr))
+	require.Equal(t, 6, columnErr.Index)
+	require.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+	require.Contains(t, err.Error(), "column #7")
+}
+
+func TestAllErrorsScanStruct(t *testing.T) {
+	db := makeusers(t)
+
+	type BadUser struct {
+		ID        int64
+		FirstName *int64
+		LastName  *time.Time
+	}
+
+	rows, err := db.Query("SELECT id, first_name, last_name FROM users WHERE id=1")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	var user BadUser
+	err = sqlnull.NewConfig(sqlnull.WithAllErrors()).ScanStruct(rows, &user)
+	require.Error(t, err)
+	require.Equal(t, int64(1), user.ID)
+	require.Contains(t, err.Error(), `column #1 "first_name"`)
+	require.Contains(t, err.Error(), `column #2 "last_name"`)
+}
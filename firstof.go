@@ -0,0 +1,56 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// firstOf scans a column into the first of several targets that accepts it.
+type firstOf struct {
+	targets []any
+	config  *Config
+}
+
+// Scan implements the sql.Scanner interface for firstOf.
+func (f *firstOf) Scan(src any) error {
+	var errs []error
+	for i, target := range f.targets {
+		err := f.config.scan(target, src)
+		if err == nil {
+			// clear the other targets, so a value left from a previous row does not
+			// look like the one chosen for this row
+			zeroTargets(f.targets[:i]...)
+			zeroTargets(f.targets[i+1:]...)
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("no target accepts %T value: %w", src, err)
+	}
+	return fmt.Errorf("no target accepts %T value", src)
+}
+
+// FirstOf returns a scanner that tries the targets in order and fills the first one whose conversion succeeds.
+// The other targets are set to their zero value, so a pointer left nil tells which one was not chosen.
+//
+// It is meant for columns whose representation varies between rows or drivers, e.g. trying a
+// time.Time target and falling back to the raw string:
+//
+//	var at *time.Time
+//	var raw *string
+//	err = row.Scan(sqlnull.FirstOf(&at, &raw))
+func FirstOf(targets ...any) sql.Scanner {
+	return Default().FirstOf(targets...)
+}
+
+// FirstOf returns a scanner that tries the targets in order and fills the first one whose conversion succeeds.
+func (c *Config) FirstOf(targets ...any) sql.Scanner {
+	return &firstOf{
+		targets: targets,
+		config:  c,
+	}
+}
@@ -0,0 +1,49 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstOf(t *testing.T) {
+	var at *time.Time
+	var raw *string
+
+	err := sqlnull.FirstOf(&at, &raw).Scan("not a time")
+	require.NoError(t, err)
+	require.Nil(t, at)
+	require.Equal(t, "not a time", *raw)
+
+	now := time.Now()
+	raw = nil
+	err = sqlnull.FirstOf(&at, &raw).Scan(now)
+	require.NoError(t, err)
+	require.Equal(t, now, *at)
+	require.Nil(t, raw)
+
+	var plain string
+	err = sqlnull.FirstOf(&at, &plain).Scan("fallback")
+	require.NoError(t, err)
+	require.Equal(t, "fallback", plain)
+
+	var num *int64
+	err = sqlnull.FirstOf(&at, &num).Scan("lorem ipsum")
+	require.Error(t, err)
+
+	// reusing the targets for the next row clears the one not chosen
+	scanner := sqlnull.FirstOf(&at, &raw)
+	require.NoError(t, scanner.Scan(now))
+	require.NotNil(t, at)
+	err = scanner.Scan("later")
+	require.NoError(t, err)
+	require.Nil(t, at)
+	require.Equal(t, "later", *raw)
+
+	err = sqlnull.FirstOf().Scan("lorem ipsum")
+	require.EqualError(t, err, "no target accepts string value")
+}
@@ -0,0 +1,74 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// maxFormMemory is the multipart memory limit used by DecodeForm, matching net/http.
+const maxFormMemory = 32 << 20
+
+// DecodeForm parses the form of r and fills the struct pointed to by dest like DecodeMap.
+// Empty form values become nil pointers, the others are converted by the same engine as
+// Scan, and fields missing from the form are left untouched, so a struct of pointers
+// decoded from a PATCH request holds exactly the fields the client sent:
+//
+//	var patch struct {
+//		Username *string
+//		Phone    *string
+//		Age      *int
+//	}
+//	err := sqlnull.DecodeForm(r, &patch)
+func DecodeForm(r *http.Request, dest any) error {
+	return Default().DecodeForm(r, dest)
+}
+
+// DecodeForm parses the form of r and fills the struct pointed to by dest like DecodeMap.
+func (c *Config) DecodeForm(r *http.Request, dest any) error {
+	if err := r.ParseMultipartForm(maxFormMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return err
+	}
+	return c.DecodeMap(formMap(r.Form), dest)
+}
+
+// DecodeQuery fills the struct pointed to by dest from URL query parameters like DecodeForm.
+// Optional fields tell the three cases apart, so a list endpoint can offer filters that
+// are either not provided, provided empty to match NULL, or provided with a value:
+//
+//	var filter struct {
+//		Phone sqlnull.Optional[string]
+//		Age   sqlnull.Optional[int]
+//	}
+//	err := sqlnull.DecodeQuery(r.URL.Query(), &filter)
+//	// ?phone=&age=30 gives filter.Phone.IsNull() and filter.Age.V == 30
+func DecodeQuery(query url.Values, dest any) error {
+	return Default().DecodeQuery(query, dest)
+}
+
+// DecodeQuery fills the struct pointed to by dest from URL query parameters like DecodeForm.
+func (c *Config) DecodeQuery(query url.Values, dest any) error {
+	return c.DecodeMap(formMap(query), dest)
+}
+
+// formMap turns form values into a map for DecodeMap, with empty values as nil
+// and repeated keys as a []any.
+func formMap(form map[string][]string) map[string]any {
+	m := make(map[string]any, len(form))
+	for key, values := range form {
+		items := make([]any, len(values))
+		for i, value := range values {
+			if value != "" {
+				items[i] = value
+			}
+		}
+		if len(items) == 1 {
+			m[key] = items[0]
+		} else {
+			m[key] = items
+		}
+	}
+	return m
+}
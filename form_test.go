@@ -0,0 +1,70 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type formPatch struct {
+	Username   *string
+	Phone      *string
+	Age        *int
+	Verified   *bool
+	VerifiedAt *time.Time `db:"verified_at,parse=date"`
+	Tags       []string
+	IDs        []int64 `db:"id"`
+}
+
+func TestDecodeForm(t *testing.T) {
+	form := url.Values{
+		"username":    {"johndoe"},
+		"phone":       {""},
+		"age":         {"30"},
+		"verified_at": {"2024-08-01"},
+		"tags":        {"admin"},
+		"id":          {"1", "2"},
+	}
+	r := httptest.NewRequest(http.MethodPatch, "/users/1", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	patch := formPatch{Phone: sqlnull.Ptr("123456789"), Verified: sqlnull.Ptr(true)}
+	require.NoError(t, sqlnull.DecodeForm(r, &patch))
+	require.Equal(t, formPatch{
+		Username:   sqlnull.Ptr("johndoe"),
+		Age:        sqlnull.Ptr(30),
+		Verified:   sqlnull.Ptr(true),
+		VerifiedAt: sqlnull.Ptr(time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)),
+		Tags:       []string{"admin"},
+		IDs:        []int64{1, 2},
+	}, patch)
+}
+
+func TestDecodeFormQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/users?age=old", nil)
+
+	var patch formPatch
+	require.ErrorContains(t, sqlnull.DecodeForm(r, &patch), `key "age"`)
+}
+
+func TestDecodeQuery(t *testing.T) {
+	var filter struct {
+		Username sqlnull.Optional[string]
+		Phone    sqlnull.Optional[string]
+		Age      sqlnull.Optional[int]
+	}
+	require.NoError(t, sqlnull.DecodeQuery(url.Values{"phone": {""}, "age": {"30"}}, &filter))
+	require.False(t, filter.Username.Set)
+	require.True(t, filter.Phone.IsNull())
+	require.Equal(t, sqlnull.Some(30), filter.Age)
+
+	require.Error(t, sqlnull.DecodeQuery(url.Values{"age": {"old"}}, &filter))
+}
@@ -0,0 +1,515 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// GeometryKind is the type of a Geometry, numbered as in well-known binary.
+type GeometryKind uint32
+
+const (
+	GeometryPoint GeometryKind = iota + 1
+	GeometryLineString
+	GeometryPolygon
+	GeometryMultiPoint
+	GeometryMultiLineString
+	GeometryMultiPolygon
+	GeometryCollection
+)
+
+var geometryKinds = []string{"", "POINT", "LINESTRING", "POLYGON", "MULTIPOINT", "MULTILINESTRING", "MULTIPOLYGON", "GEOMETRYCOLLECTION"}
+
+// String returns the WKT tag of k, e.g. POINT.
+func (k GeometryKind) String() string {
+	if int(k) < len(geometryKinds) {
+		return geometryKinds[k]
+	}
+	return fmt.Sprintf("GeometryKind(%d)", uint32(k))
+}
+
+// Point is a 2D coordinate.
+type Point struct {
+	X, Y float64
+}
+
+// Geometry holds a 2D value of a PostGIS geometry/geography or MySQL spatial column.
+// It scans from well-known binary, PostGIS EWKB, including its hex text form, the MySQL
+// internal format, which prefixes WKB with the SRID, and WKT or EWKT text. It is written as
+// WKB, so statements wrap the argument with ST_GeomFromWKB(?, srid). NULL scans into the
+// zero Geometry, which is written as NULL; use a *Geometry to tell NULL apart.
+//
+//	var area sqlnull.Geometry
+//	err = db.QueryRow("SELECT boundary FROM regions WHERE id=$1", id).Scan(&area)
+type Geometry struct {
+	Kind   GeometryKind
+	SRID   int        // spatial reference ID, 0 when unknown
+	Points []Point    // the point of a Point, the vertices of a LineString, the points of a MultiPoint
+	Rings  [][]Point  // the rings of a Polygon, the lines of a MultiLineString
+	Parts  []Geometry // the polygons of a MultiPolygon, the members of a GeometryCollection
+}
+
+// ParseGeometry parses the WKT or EWKT text of a geometry, e.g. "SRID=4326;POINT(1 2)".
+func ParseGeometry(s string) (Geometry, error) {
+	p := &wktParser{s: s}
+	srid := 0
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(s)), "SRID=") {
+		prefix, rest, ok := strings.Cut(strings.TrimSpace(s), ";")
+		n, err := strconv.Atoi(prefix[len("SRID="):])
+		if !ok || err != nil {
+			return Geometry{}, fmt.Errorf("invalid EWKT %q", s)
+		}
+		p.s, srid = rest, n
+	}
+	g, err := p.geometry()
+	if err == nil && p.token() != "" {
+		err = fmt.Errorf("unexpected %q", p.token())
+	}
+	if err != nil {
+		return Geometry{}, fmt.Errorf("invalid WKT %q: %w", s, err)
+	}
+	g.SRID = srid
+	return g, nil
+}
+
+// String returns the WKT text of g, without its SRID.
+func (g Geometry) String() string {
+	if g.Kind == 0 {
+		return ""
+	}
+	var b strings.Builder
+	g.writeWKT(&b)
+	return b.String()
+}
+
+// WKB returns the little-endian well-known binary encoding of g, without its SRID.
+func (g Geometry) WKB() []byte {
+	return g.appendWKB(nil)
+}
+
+// Scan implements the sql.Scanner interface for Geometry.
+func (g *Geometry) Scan(src any) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*g = Geometry{}
+		return nil
+	case []byte:
+		*g, err = decodeGeometry(v)
+	case string:
+		if b, hexErr := hex.DecodeString(v); hexErr == nil && len(v) > 0 {
+			*g, err = decodeGeometry(b)
+		} else {
+			*g, err = ParseGeometry(v)
+		}
+	default:
+		return fmt.Errorf("cannot scan %T value into Geometry", src)
+	}
+	return err
+}
+
+// Value implements the driver.Valuer interface for Geometry.
+func (g Geometry) Value() (driver.Value, error) {
+	if g.Kind == 0 {
+		return nil, nil
+	}
+	return g.WKB(), nil
+}
+
+// decodeGeometry decodes WKB or EWKB, falling back to the MySQL internal format.
+func decodeGeometry(b []byte) (Geometry, error) {
+	r := &wkbReader{b: b}
+	g, err := r.geometry()
+	if err == nil && len(r.b) == 0 {
+		return g, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("%d trailing bytes", len(r.b))
+	}
+	if len(b) > 4 {
+		mysql := &wkbReader{b: b[4:]}
+		if g, mysqlErr := mysql.geometry(); mysqlErr == nil && len(mysql.b) == 0 {
+			g.SRID = int(binary.LittleEndian.Uint32(b))
+			return g, nil
+		}
+	}
+	return Geometry{}, fmt.Errorf("invalid WKB geometry: %w", err)
+}
+
+// EWKB flags set in the geometry type.
+const (
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// wkbReader consumes well-known binary.
+type wkbReader struct {
+	b     []byte
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if len(r.b) < 4 {
+		return 0, fmt.Errorf("unexpected end of data")
+	}
+	v := r.order.Uint32(r.b)
+	r.b = r.b[4:]
+	return v, nil
+}
+
+// count reads a number of elements taking at least size bytes each.
+func (r *wkbReader) count(size int) (int, error) {
+	n, err := r.uint32()
+	if err == nil && int64(n)*int64(size) > int64(len(r.b)) {
+		err = fmt.Errorf("count %d exceeds data", n)
+	}
+	return int(n), err
+}
+
+func (r *wkbReader) points() ([]Point, error) {
+	n, err := r.count(16)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = r.point()
+	}
+	return points, nil
+}
+
+func (r *wkbReader) point() Point {
+	p := Point{
+		X: math.Float64frombits(r.order.Uint64(r.b)),
+		Y: math.Float64frombits(r.order.Uint64(r.b[8:])),
+	}
+	r.b = r.b[16:]
+	return p
+}
+
+func (r *wkbReader) geometry() (Geometry, error) {
+	var g Geometry
+	if len(r.b) < 1 {
+		return g, fmt.Errorf("unexpected end of data")
+	}
+	switch r.b[0] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return g, fmt.Errorf("invalid byte order %d", r.b[0])
+	}
+	r.b = r.b[1:]
+
+	typ, err := r.uint32()
+	if err != nil {
+		return g, err
+	}
+	if typ&ewkbSRID != 0 {
+		srid, err := r.uint32()
+		if err != nil {
+			return g, err
+		}
+		g.SRID = int(srid)
+	}
+	if typ&(ewkbZ|ewkbM) != 0 || typ&0xffff >= 1000 {
+		return g, fmt.Errorf("geometries with Z or M coordinates are not supported")
+	}
+	g.Kind = GeometryKind(typ & 0xffff)
+
+	switch g.Kind {
+	case GeometryPoint:
+		if len(r.b) < 16 {
+			return g, fmt.Errorf("unexpected end of data")
+		}
+		// an empty point is written with NaN coordinates
+		if p := r.point(); !math.IsNaN(p.X) || !math.IsNaN(p.Y) {
+			g.Points = []Point{p}
+		}
+	case GeometryLineString:
+		g.Points, err = r.points()
+	case GeometryPolygon:
+		var n int
+		if n, err = r.count(4); err == nil {
+			g.Rings = make([][]Point, n)
+			for i := 0; i < n && err == nil; i++ {
+				g.Rings[i], err = r.points()
+			}
+		}
+	case GeometryMultiPoint, GeometryMultiLineString, GeometryMultiPolygon, GeometryCollection:
+		var n int
+		if n, err = r.count(5); err != nil {
+			return g, err
+		}
+		for i := 0; i < n; i++ {
+			part, err := r.geometry()
+			if err != nil {
+				return g, err
+			}
+			switch {
+			case g.Kind == GeometryMultiPoint && part.Kind == GeometryPoint:
+				g.Points = append(g.Points, part.Points...)
+			case g.Kind == GeometryMultiLineString && part.Kind == GeometryLineString:
+				g.Rings = append(g.Rings, part.Points)
+			case g.Kind == GeometryMultiPolygon && part.Kind == GeometryPolygon, g.Kind == GeometryCollection:
+				g.Parts = append(g.Parts, part)
+			default:
+				return g, fmt.Errorf("%s cannot hold a %s", g.Kind, part.Kind)
+			}
+		}
+	default:
+		return g, fmt.Errorf("unknown geometry type %d", typ)
+	}
+	return g, err
+}
+
+func (g Geometry) appendWKB(b []byte) []byte {
+	b = append(b, 1)
+	b = binary.LittleEndian.AppendUint32(b, uint32(g.Kind))
+	switch g.Kind {
+	case GeometryPoint:
+		p := Point{math.NaN(), math.NaN()}
+		if len(g.Points) > 0 {
+			p = g.Points[0]
+		}
+		b = appendPoint(b, p)
+	case GeometryLineString:
+		b = appendPoints(b, g.Points)
+	case GeometryPolygon:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(g.Rings)))
+		for _, ring := range g.Rings {
+			b = appendPoints(b, ring)
+		}
+	case GeometryMultiPoint:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(g.Points)))
+		for _, p := range g.Points {
+			b = Geometry{Kind: GeometryPoint, Points: []Point{p}}.appendWKB(b)
+		}
+	case GeometryMultiLineString:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(g.Rings)))
+		for _, line := range g.Rings {
+			b = Geometry{Kind: GeometryLineString, Points: line}.appendWKB(b)
+		}
+	case GeometryMultiPolygon, GeometryCollection:
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(g.Parts)))
+		for _, part := range g.Parts {
+			b = part.appendWKB(b)
+		}
+	}
+	return b
+}
+
+func appendPoints(b []byte, points []Point) []byte {
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(points)))
+	for _, p := range points {
+		b = appendPoint(b, p)
+	}
+	return b
+}
+
+func appendPoint(b []byte, p Point) []byte {
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(p.X))
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(p.Y))
+}
+
+func (g Geometry) writeWKT(b *strings.Builder) {
+	b.WriteString(g.Kind.String())
+	if len(g.Points) == 0 && len(g.Rings) == 0 && len(g.Parts) == 0 {
+		b.WriteString(" EMPTY")
+		return
+	}
+	b.WriteByte('(')
+	switch g.Kind {
+	case GeometryPoint, GeometryLineString, GeometryMultiPoint:
+		writePoints(b, g.Points)
+	case GeometryPolygon, GeometryMultiLineString:
+		writeRings(b, g.Rings)
+	case GeometryMultiPolygon:
+		for i, part := range g.Parts {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteByte('(')
+			writeRings(b, part.Rings)
+			b.WriteByte(')')
+		}
+	case GeometryCollection:
+		for i, part := range g.Parts {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			part.writeWKT(b)
+		}
+	}
+	b.WriteByte(')')
+}
+
+func writeRings(b *strings.Builder, rings [][]Point) {
+	for i, ring := range rings {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('(')
+		writePoints(b, ring)
+		b.WriteByte(')')
+	}
+}
+
+func writePoints(b *strings.Builder, points []Point) {
+	for i, p := range points {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(p.X, 'f', -1, 64))
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatFloat(p.Y, 'f', -1, 64))
+	}
+}
+
+// wktParser reads well-known text.
+type wktParser struct {
+	s string
+}
+
+// token returns the next token without consuming it: a parenthesis, a comma, or a word or number.
+func (p *wktParser) token() string {
+	p.s = strings.TrimSpace(p.s)
+	if p.s == "" {
+		return ""
+	}
+	if strings.ContainsRune("(),", rune(p.s[0])) {
+		return p.s[:1]
+	}
+	end := strings.IndexAny(p.s, "(), \t\n")
+	if end < 0 {
+		end = len(p.s)
+	}
+	return p.s[:end]
+}
+
+func (p *wktParser) next() string {
+	tok := p.token()
+	p.s = p.s[len(tok):]
+	return tok
+}
+
+func (p *wktParser) expect(tok string) error {
+	if got := p.next(); got != tok {
+		return fmt.Errorf("expected %q, got %q", tok, got)
+	}
+	return nil
+}
+
+// list parses a parenthesized, comma separated list, calling item for each element.
+func (p *wktParser) list(item func() error) error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+		if p.token() != "," {
+			return p.expect(")")
+		}
+		p.next()
+	}
+}
+
+func (p *wktParser) point() (Point, error) {
+	x, err := strconv.ParseFloat(p.next(), 64)
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := strconv.ParseFloat(p.next(), 64)
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{x, y}, nil
+}
+
+func (p *wktParser) points() ([]Point, error) {
+	var points []Point
+	err := p.list(func() error {
+		// MULTIPOINT allows both (1 2,3 4) and ((1 2),(3 4))
+		parens := p.token() == "("
+		if parens {
+			p.next()
+		}
+		pt, err := p.point()
+		if err != nil {
+			return err
+		}
+		points = append(points, pt)
+		if parens {
+			return p.expect(")")
+		}
+		return nil
+	})
+	return points, err
+}
+
+func (p *wktParser) rings() ([][]Point, error) {
+	var rings [][]Point
+	err := p.list(func() error {
+		ring, err := p.points()
+		rings = append(rings, ring)
+		return err
+	})
+	return rings, err
+}
+
+func (p *wktParser) geometry() (Geometry, error) {
+	var g Geometry
+	tag := strings.ToUpper(p.next())
+	for i, name := range geometryKinds {
+		if name != "" && name == tag {
+			g.Kind = GeometryKind(i)
+		}
+	}
+	if g.Kind == 0 {
+		return g, fmt.Errorf("unknown geometry type %q", tag)
+	}
+	switch strings.ToUpper(p.token()) {
+	case "Z", "M", "ZM":
+		return g, fmt.Errorf("geometries with Z or M coordinates are not supported")
+	case "EMPTY":
+		p.next()
+		return g, nil
+	}
+
+	var err error
+	switch g.Kind {
+	case GeometryPoint:
+		g.Points, err = p.points()
+		if err == nil && len(g.Points) != 1 {
+			err = fmt.Errorf("POINT needs a single coordinate")
+		}
+	case GeometryLineString, GeometryMultiPoint:
+		g.Points, err = p.points()
+	case GeometryPolygon, GeometryMultiLineString:
+		g.Rings, err = p.rings()
+	case GeometryMultiPolygon:
+		err = p.list(func() error {
+			rings, err := p.rings()
+			g.Parts = append(g.Parts, Geometry{Kind: GeometryPolygon, Rings: rings})
+			return err
+		})
+	case GeometryCollection:
+		err = p.list(func() error {
+			part, err := p.geometry()
+			g.Parts = append(g.Parts, part)
+			return err
+		})
+	}
+	return g, err
+}
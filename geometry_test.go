@@ -0,0 +1,85 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeometry(t *testing.T) {
+	var g sqlnull.Geometry
+
+	// PostGIS hex EWKB for SRID=4326;POINT(1 2)
+	require.NoError(t, g.Scan("0101000020E6100000000000000000F03F0000000000000040"))
+	require.Equal(t, sqlnull.Geometry{Kind: sqlnull.GeometryPoint, SRID: 4326, Points: []sqlnull.Point{{1, 2}}}, g)
+	require.Equal(t, "POINT(1 2)", g.String())
+
+	// MySQL internal format: SRID followed by WKB
+	mysql, err := hex.DecodeString("E6100000" + "0101000000000000000000F03F0000000000000040")
+	require.NoError(t, err)
+	require.NoError(t, g.Scan(mysql))
+	require.Equal(t, sqlnull.Geometry{Kind: sqlnull.GeometryPoint, SRID: 4326, Points: []sqlnull.Point{{1, 2}}}, g)
+
+	for _, wkt := range []string{
+		"POINT EMPTY",
+		"LINESTRING(0 0,1.5 -2)",
+		"POLYGON((0 0,4 0,4 4,0 0),(1 1,2 1,1 2,1 1))",
+		"MULTIPOINT(1 2,3 4)",
+		"MULTILINESTRING((0 0,1 1),(2 2,3 3))",
+		"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))",
+		"GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(0 0,1 1))",
+	} {
+		parsed, err := sqlnull.ParseGeometry(wkt)
+		require.NoError(t, err, wkt)
+		require.Equal(t, wkt, parsed.String())
+
+		var decoded sqlnull.Geometry
+		require.NoError(t, decoded.Scan(parsed.WKB()), wkt)
+		require.Equal(t, parsed, decoded, wkt)
+	}
+
+	require.NoError(t, g.Scan("SRID=3857; multipoint((1 2), (3 4))"))
+	require.Equal(t, 3857, g.SRID)
+	require.Equal(t, []sqlnull.Point{{1, 2}, {3, 4}}, g.Points)
+
+	require.NoError(t, g.Scan(nil))
+	require.Equal(t, sqlnull.Geometry{}, g)
+	v, err := g.Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	require.ErrorContains(t, g.Scan("POINT Z(1 2 3)"), "Z or M")
+	require.ErrorContains(t, g.Scan("CIRCLE(1 2)"), "unknown geometry type")
+	require.ErrorContains(t, g.Scan([]byte{1, 1, 0, 0, 0}), "invalid WKB")
+	require.ErrorContains(t, g.Scan(int64(1)), "cannot scan")
+}
+
+func TestScanStructGeometry(t *testing.T) {
+	db := makeusers(t)
+
+	type Place struct {
+		ID       int64
+		Location *sqlnull.Geometry
+	}
+
+	rows, err := db.Query("SELECT id, CASE id WHEN 1 THEN ? END AS location FROM users ORDER BY id",
+		sqlnull.Geometry{Kind: sqlnull.GeometryPoint, Points: []sqlnull.Point{{3, 4}}})
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var got []Place
+	for rows.Next() {
+		var place Place
+		require.NoError(t, sqlnull.ScanStruct(rows, &place))
+		got = append(got, place)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []Place{
+		{ID: 1, Location: &sqlnull.Geometry{Kind: sqlnull.GeometryPoint, Points: []sqlnull.Point{{3, 4}}}},
+		{ID: 2},
+	}, got)
+}
@@ -0,0 +1,86 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"fmt"
+)
+
+// Gob stores a Go value gob-encoded in a BLOB column, for tools that persist Go values
+// opaquely. It implements sql.Scanner and driver.Valuer; NULL leaves Valid false and V
+// at its zero value, and an invalid Gob is written as NULL.
+type Gob[T any] struct {
+	V     T
+	Valid bool
+}
+
+// Scan implements the sql.Scanner interface for Gob.
+func (g *Gob[T]) Scan(src any) error {
+	var zero T
+	g.V, g.Valid = zero, false
+
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("cannot gob-decode %T value into %T", src, zero)
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&g.V); err != nil {
+		return err
+	}
+	g.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface for Gob.
+func (g Gob[T]) Value() (driver.Value, error) {
+	if !g.Valid {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(g.V); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalMsgpack implements the msgpack.Marshaler interface for Gob, encoding V directly,
+// or nil when Gob is invalid. It requires the nullmsgpack package to be imported.
+func (g Gob[T]) MarshalMsgpack() ([]byte, error) {
+	return marshalNullable("msgpack", g.V, g.Valid)
+}
+
+// UnmarshalMsgpack implements the msgpack.Unmarshaler interface for Gob.
+func (g *Gob[T]) UnmarshalMsgpack(data []byte) error {
+	v, valid, err := unmarshalNullable[T]("msgpack", data)
+	if err != nil {
+		return err
+	}
+	g.V, g.Valid = v, valid
+	return nil
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface for Gob, encoding V directly,
+// or null when Gob is invalid. It requires the nullcbor package to be imported.
+func (g Gob[T]) MarshalCBOR() ([]byte, error) {
+	return marshalNullable("cbor", g.V, g.Valid)
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface for Gob.
+func (g *Gob[T]) UnmarshalCBOR(data []byte) error {
+	v, valid, err := unmarshalNullable[T]("cbor", data)
+	if err != nil {
+		return err
+	}
+	g.V, g.Valid = v, valid
+	return nil
+}
@@ -0,0 +1,42 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type Settings struct {
+	Theme  string
+	Limits map[string]int
+}
+
+func TestGob(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE [prefs] ([id] INTEGER, [settings] BLOB)`)
+	require.NoError(t, err)
+
+	settings := sqlnull.Gob[Settings]{V: Settings{Theme: "dark", Limits: map[string]int{"rows": 50}}, Valid: true}
+	_, err = db.Exec("INSERT INTO prefs (id, settings) VALUES (?, ?), (?, ?)", 1, settings, 2, sqlnull.Gob[Settings]{})
+	require.NoError(t, err)
+
+	var got sqlnull.Gob[Settings]
+	require.NoError(t, db.QueryRow("SELECT settings FROM prefs WHERE id=1").Scan(&got))
+	require.True(t, got.Valid)
+	require.Equal(t, settings.V, got.V)
+
+	require.NoError(t, db.QueryRow("SELECT settings FROM prefs WHERE id=2").Scan(&got))
+	require.False(t, got.Valid)
+	require.Equal(t, Settings{}, got.V)
+
+	require.Error(t, got.Scan([]byte("not gob")))
+	require.Error(t, got.Scan(int64(1)))
+}
@@ -0,0 +1,151 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+// Hash returns a stable 64-bit hash of v, typically a scanned struct, for change detection
+// and deduplication. NULL is hashed the same however it is represented, be it a nil pointer,
+// an invalid sql.Null type or an Optional without a value, and differently from any zero
+// value, so a column going from NULL to 0 or "" changes the hash. Present values hash the
+// same through pointers and Null wrappers, and integers and floats hash by value whatever
+// their size, integers whatever their signedness. Struct fields are hashed along with their names, maps regardless of order.
+func Hash(v any) uint64 {
+	h := fnv.New64a()
+	hashValue(h, reflect.ValueOf(v))
+	return h.Sum64()
+}
+
+// Tags written ahead of each hashed value, keeping values of different kinds apart.
+const (
+	hashNull byte = iota
+	hashBool
+	hashInt // negative integers
+	hashUint
+	hashFloat
+	hashComplex
+	hashString
+	hashTime
+	hashRat
+	hashList
+	hashMap
+	hashStruct
+	hashOther
+)
+
+// hashValue writes v to h.
+func hashValue(h hash.Hash64, v reflect.Value) {
+	var buf [8]byte
+	writeUint := func(tag byte, u uint64) {
+		binary.BigEndian.PutUint64(buf[:], u)
+		h.Write([]byte{tag})
+		h.Write(buf[:])
+	}
+	writeString := func(tag byte, s string) {
+		writeUint(tag, uint64(len(s)))
+		h.Write([]byte(s))
+	}
+
+	for {
+		if !v.IsValid() || isNil(v) {
+			h.Write([]byte{hashNull})
+			return
+		}
+		switch {
+		case v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface:
+			v = v.Elem()
+			continue
+		case isNullWrapper(v.Type()):
+			v = v.Field(0)
+			continue
+		case v.Type().Implements(optionalType):
+			value, valid := v.Interface().(optional).optional()
+			if !valid {
+				h.Write([]byte{hashNull})
+				return
+			}
+			v = reflect.ValueOf(value)
+			continue
+		}
+		break
+	}
+
+	switch v.Type() {
+	case timeType:
+		t := v.Interface().(time.Time)
+		writeUint(hashTime, uint64(t.Unix()))
+		writeUint(hashTime, uint64(t.Nanosecond()))
+		return
+	case ratType:
+		r := new(big.Rat)
+		reflect.ValueOf(r).Elem().Set(v)
+		writeString(hashRat, r.RatString())
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		var b uint64
+		if v.Bool() {
+			b = 1
+		}
+		writeUint(hashBool, b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// non-negative values hash like unsigned ones, so int64(5) and uint64(5) are equal
+		if i := v.Int(); i < 0 {
+			writeUint(hashInt, uint64(i))
+		} else {
+			writeUint(hashUint, uint64(i))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(hashUint, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint(hashFloat, math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		writeUint(hashComplex, math.Float64bits(real(v.Complex())))
+		writeUint(hashComplex, math.Float64bits(imag(v.Complex())))
+	case reflect.String:
+		writeString(hashString, v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 && v.Kind() == reflect.Slice {
+			writeString(hashString, string(v.Bytes()))
+			return
+		}
+		writeUint(hashList, uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Map:
+		// entries are hashed on their own and summed, so their order does not matter
+		var sum uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			entry := fnv.New64a()
+			hashValue(entry, iter.Key())
+			hashValue(entry, iter.Value())
+			sum += entry.Sum64()
+		}
+		writeUint(hashMap, uint64(v.Len()))
+		writeUint(hashMap, sum)
+	case reflect.Struct:
+		writeUint(hashStruct, uint64(v.NumField()))
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			writeString(hashStruct, field.Name)
+			hashValue(h, v.Field(i))
+		}
+	default:
+		writeString(hashOther, v.Type().String())
+	}
+}
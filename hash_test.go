@@ -0,0 +1,49 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHash(t *testing.T) {
+	type Row struct {
+		ID    int64
+		Phone *string
+		Tags  map[string]int
+	}
+
+	base := sqlnull.Hash(Row{ID: 1, Tags: map[string]int{"a": 1, "b": 2}})
+	require.Equal(t, base, sqlnull.Hash(Row{ID: 1, Tags: map[string]int{"b": 2, "a": 1}}))
+	require.Equal(t, base, sqlnull.Hash(&Row{ID: 1, Tags: map[string]int{"a": 1, "b": 2}}))
+	require.NotEqual(t, base, sqlnull.Hash(Row{ID: 1, Phone: sqlnull.Ptr(""), Tags: map[string]int{"a": 1, "b": 2}}))
+	require.NotEqual(t, base, sqlnull.Hash(Row{ID: 2, Tags: map[string]int{"a": 1, "b": 2}}))
+
+	// NULL is canonical and differs from the zero value
+	null := sqlnull.Hash(nil)
+	require.Equal(t, null, sqlnull.Hash((*string)(nil)))
+	require.Equal(t, null, sqlnull.Hash(sql.NullInt64{}))
+	require.Equal(t, null, sqlnull.Hash(sqlnull.Optional[int]{Set: true}))
+	require.NotEqual(t, null, sqlnull.Hash(0))
+	require.NotEqual(t, null, sqlnull.Hash(""))
+
+	// present values hash the same however they are wrapped
+	require.Equal(t, sqlnull.Hash(int64(7)), sqlnull.Hash(sqlnull.Ptr(int32(7))))
+	require.Equal(t, sqlnull.Hash(int64(7)), sqlnull.Hash(sql.NullInt32{Int32: 7, Valid: true}))
+	require.Equal(t, sqlnull.Hash("lorem"), sqlnull.Hash(sqlnull.Some("lorem")))
+	require.NotEqual(t, sqlnull.Hash("7"), sqlnull.Hash(7))
+	require.Equal(t, sqlnull.Hash(int64(5)), sqlnull.Hash(uint64(5)))
+	require.Equal(t, sqlnull.Hash(int8(5)), sqlnull.Hash(sql.NullByte{Byte: 5, Valid: true}))
+	require.NotEqual(t, sqlnull.Hash(int64(-1)), sqlnull.Hash(uint64(math.MaxUint64)))
+
+	now := time.Now()
+	require.Equal(t, sqlnull.Hash(now), sqlnull.Hash(sql.NullTime{Time: now.UTC(), Valid: true}))
+	require.Equal(t, sqlnull.Hash(big.NewRat(1, 2)), sqlnull.Hash(big.NewRat(2, 4)))
+}
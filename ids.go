@@ -0,0 +1,183 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KSUID is a K-Sortable Unique IDentifier as generated by github.com/segmentio/ksuid:
+// a 4-byte timestamp followed by a 16-byte random payload. It scans from its 27-character
+// base62 text form or from the 20 raw bytes, and is written as text. NULL scans into the
+// zero KSUID; use a *KSUID or sql.Null[KSUID] to tell NULL apart.
+type KSUID [20]byte
+
+const (
+	ksuidEncodedLen = 27
+	ksuidEpoch      = 1400000000
+)
+
+// ParseKSUID parses the 27-character base62 text form of a KSUID.
+func ParseKSUID(s string) (KSUID, error) {
+	var k KSUID
+	if len(s) != ksuidEncodedLen {
+		return k, fmt.Errorf("invalid KSUID %q: length %d, want %d", s, len(s), ksuidEncodedLen)
+	}
+	// big.Int uses the digits 0-9a-zA-Z for base 62, KSUIDs use 0-9A-Za-z
+	n, ok := new(big.Int).SetString(swapCase(s), 62)
+	if !ok || n.BitLen() > len(k)*8 {
+		return k, fmt.Errorf("invalid KSUID %q", s)
+	}
+	n.FillBytes(k[:])
+	return k, nil
+}
+
+// String returns the 27-character base62 text form of k.
+func (k KSUID) String() string {
+	s := swapCase(new(big.Int).SetBytes(k[:]).Text(62))
+	return strings.Repeat("0", ksuidEncodedLen-len(s)) + s
+}
+
+// Time returns the time k was generated, with second precision.
+func (k KSUID) Time() time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint32(k[:4]))+ksuidEpoch, 0)
+}
+
+// IsZero reports whether k is the zero KSUID.
+func (k KSUID) IsZero() bool {
+	return k == KSUID{}
+}
+
+// Scan implements the sql.Scanner interface for KSUID.
+func (k *KSUID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*k = KSUID{}
+		return nil
+	case string:
+		return k.UnmarshalText([]byte(v))
+	case []byte:
+		if len(v) == len(k) {
+			copy(k[:], v)
+			return nil
+		}
+		return k.UnmarshalText(v)
+	}
+	return fmt.Errorf("cannot scan %T value into KSUID", src)
+}
+
+// Value implements the driver.Valuer interface for KSUID.
+func (k KSUID) Value() (driver.Value, error) {
+	return k.String(), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for KSUID.
+func (k KSUID) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for KSUID.
+func (k *KSUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseKSUID(string(text))
+	if err != nil {
+		return err
+	}
+	*k = parsed
+	return nil
+}
+
+// swapCase swaps the case of the ASCII letters in s.
+func swapCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return r
+	}, s)
+}
+
+// Snowflake is a Twitter-style Snowflake ID: a non-negative int64 holding a millisecond
+// timestamp in its upper 41 bits. It scans from integer columns and from decimal text of
+// up to 20 characters, as the IDs are often stored as strings, rejecting negative values.
+// It is written as int64, and as a JSON string through MarshalText so JavaScript clients
+// do not lose precision. NULL scans into 0; use a *Snowflake or sql.Null[Snowflake] to
+// tell NULL apart.
+type Snowflake int64
+
+// Epochs of well-known Snowflake ID schemes, for Snowflake.Time.
+var (
+	TwitterEpoch = time.UnixMilli(1288834974657)
+	DiscordEpoch = time.UnixMilli(1420070400000)
+)
+
+// ParseSnowflake parses the decimal text form of a Snowflake ID.
+func ParseSnowflake(s string) (Snowflake, error) {
+	if len(s) == 0 || len(s) > 20 {
+		return 0, fmt.Errorf("invalid Snowflake ID %q", s)
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid Snowflake ID %q", s)
+	}
+	return Snowflake(n), nil
+}
+
+// String returns the decimal text form of s.
+func (s Snowflake) String() string {
+	return strconv.FormatInt(int64(s), 10)
+}
+
+// Time returns the time s was generated, counting its timestamp from epoch,
+// e.g. TwitterEpoch or DiscordEpoch.
+func (s Snowflake) Time(epoch time.Time) time.Time {
+	return epoch.Add(time.Duration(s>>22) * time.Millisecond)
+}
+
+// Scan implements the sql.Scanner interface for Snowflake.
+func (s *Snowflake) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*s = 0
+		return nil
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("invalid Snowflake ID %d", v)
+		}
+		*s = Snowflake(v)
+		return nil
+	case string:
+		return s.UnmarshalText([]byte(v))
+	case []byte:
+		return s.UnmarshalText(v)
+	}
+	return fmt.Errorf("cannot scan %T value into Snowflake", src)
+}
+
+// Value implements the driver.Valuer interface for Snowflake.
+func (s Snowflake) Value() (driver.Value, error) {
+	return int64(s), nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for Snowflake.
+func (s Snowflake) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for Snowflake.
+func (s *Snowflake) UnmarshalText(text []byte) error {
+	parsed, err := ParseSnowflake(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
@@ -0,0 +1,74 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKSUID(t *testing.T) {
+	// values from the github.com/segmentio/ksuid documentation
+	k, err := sqlnull.ParseKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+	require.NoError(t, err)
+	require.Equal(t, "0ujtsYcgvSTl8PAuAdqWYSMnLOv", k.String())
+	require.Equal(t, time.Date(2017, 10, 10, 4, 0, 47, 0, time.UTC), k.Time().UTC())
+	require.Equal(t, "000000000000000000000000000", sqlnull.KSUID{}.String())
+
+	maxID := sqlnull.KSUID{}
+	for i := range maxID {
+		maxID[i] = 0xff
+	}
+	require.Equal(t, "aWgEPTl1tmebfsQzFP4bxwgy80V", maxID.String())
+
+	_, err = sqlnull.ParseKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLO")
+	require.Error(t, err)
+	_, err = sqlnull.ParseKSUID("zzzzzzzzzzzzzzzzzzzzzzzzzzz")
+	require.Error(t, err)
+
+	db := makeusers(t)
+	var id *sqlnull.KSUID
+	require.NoError(t, db.QueryRow("SELECT ?", k).Scan(sqlnull.Target(&id)))
+	require.Equal(t, k, *id)
+	require.NoError(t, db.QueryRow("SELECT ?", k[:]).Scan(sqlnull.Target(&id)))
+	require.Equal(t, k, *id)
+	require.NoError(t, db.QueryRow("SELECT NULL").Scan(sqlnull.Target(&id)))
+	require.Nil(t, id)
+	require.Error(t, db.QueryRow("SELECT 'nope'").Scan(sqlnull.Target(&id)))
+
+	var null sql.Null[sqlnull.KSUID]
+	require.NoError(t, db.QueryRow("SELECT ?", k).Scan(&null))
+	require.Equal(t, sql.Null[sqlnull.KSUID]{V: k, Valid: true}, null)
+}
+
+func TestSnowflake(t *testing.T) {
+	s, err := sqlnull.ParseSnowflake("175928847299117063")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2016, 4, 30, 11, 18, 25, 796000000, time.UTC), s.Time(sqlnull.DiscordEpoch).UTC())
+
+	_, err = sqlnull.ParseSnowflake("-1")
+	require.Error(t, err)
+
+	data, err := json.Marshal(struct{ ID sqlnull.Snowflake }{s})
+	require.NoError(t, err)
+	require.Equal(t, `{"ID":"175928847299117063"}`, string(data))
+
+	db := makeusers(t)
+	var id *sqlnull.Snowflake
+	require.NoError(t, db.QueryRow("SELECT 175928847299117063").Scan(sqlnull.Target(&id)))
+	require.Equal(t, s, *id)
+	require.NoError(t, db.QueryRow("SELECT '175928847299117063'").Scan(sqlnull.Target(&id)))
+	require.Equal(t, s, *id)
+	require.NoError(t, db.QueryRow("SELECT NULL").Scan(sqlnull.Target(&id)))
+	require.Nil(t, id)
+	require.Error(t, db.QueryRow("SELECT -5").Scan(sqlnull.Target(&id)))
+
+	var plain sqlnull.Snowflake
+	require.NoError(t, db.QueryRow("SELECT ?", s).Scan(&plain))
+	require.Equal(t, s, plain)
+}
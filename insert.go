@@ -0,0 +1,98 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Insert builds an INSERT statement for the struct pointed to by v, using the same
+// column names as ScanStruct. Derived and expr fields are left out, nil pointer
+// fields are sent as NULL and types implementing encoding.BinaryMarshaler, but not
+// driver.Valuer, are marshaled. Fields tagged with the zeronull option are sent as
+// NULL when they hold their zero value, fields tagged with the set option are written as
+// the comma separated text of a MySQL SET value, and fields tagged with the redact option
+// are wrapped with Redact.
+//
+//	query, args, err := sqlnull.Insert("users", &user)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	_, err = db.Exec(query, args...)
+func Insert(table string, v any) (string, []any, error) {
+	return Default().Insert(table, v)
+}
+
+// Insert builds an INSERT statement for the struct pointed to by v, rendering the args with the write options of c.
+func (c *Config) Insert(table string, v any) (string, []any, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("Insert value must be a struct or a pointer to struct, got %T", v)
+	}
+
+	columns, args, err := c.insertValues(val)
+	if err != nil {
+		return "", nil, fmt.Errorf("Insert %w", err)
+	}
+	if len(columns) == 0 {
+		return "", nil, fmt.Errorf("Insert value %T has no columns", v)
+	}
+
+	placeholders := strings.Repeat("?, ", len(columns))
+	query := "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (" + placeholders[:len(placeholders)-2] + ")"
+	return query, args, nil
+}
+
+// insertColumns returns the fields of the struct type t that are written by Insert.
+func insertColumns(t reflect.Type) []structField {
+	var fields []structField
+	for _, field := range structFields(t) {
+		if _, ok := field.options["expr"]; ok || field.name == "" {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// insertValues returns the columns written by Insert for the struct val along with their args.
+func (c *Config) insertValues(val reflect.Value) ([]string, []any, error) {
+	var columns []string
+	var args []any
+	for _, field := range insertColumns(val.Type()) {
+		columns = append(columns, field.name)
+		var arg any
+		if fieldVal := val.FieldByIndex(field.index); !fieldVal.IsZero() || !field.hasOption("zeronull") {
+			var err error
+			if field.hasOption("set") {
+				arg, err = setArg(fieldVal)
+			} else {
+				arg, err = c.driverValue(fieldVal.Interface())
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("column %s: %w", field.name, err)
+			}
+			if field.hasOption("redact") {
+				arg = Redact(arg)
+			}
+		}
+		args = append(args, arg)
+	}
+	return columns, args, nil
+}
+
+// setArg renders a field tagged with the set option, NULL when it is a nil pointer.
+func setArg(val reflect.Value) (any, error) {
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil, nil
+		}
+		val = val.Elem()
+	}
+	return setText(val)
+}
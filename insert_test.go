@@ -0,0 +1,33 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsert(t *testing.T) {
+	db := makeusers(t)
+
+	last := "smith"
+	query, args, err := sqlnull.Insert("users", &UserView{ID: 3, FirstName: "anna", LastName: &last, FullName: "ignored"})
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO users (id, first_name, last_name, verified_at) VALUES (?, ?, ?, ?)", query)
+	_, err = db.Exec(query, args...)
+	require.NoError(t, err)
+
+	query, args, err = sqlnull.Insert("users", UserView{ID: 4, FirstName: "bob"})
+	require.NoError(t, err)
+	_, err = db.Exec(query, args...)
+	require.NoError(t, err)
+
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM users WHERE last_name IS NULL AND id=4").Scan(&count))
+	require.Equal(t, 1, count)
+
+	_, _, err = sqlnull.Insert("users", 42)
+	require.Error(t, err)
+}
@@ -0,0 +1,95 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interpolate renders query with its ? or $N placeholders replaced by args as SQL literals,
+// so the exact statement can be pasted into a SQL console while debugging. Nil pointers,
+// invalid sql.Null types and other NULL args are rendered as NULL, strings are quoted,
+// byte slices written as X'..', times quoted in 2006-01-02 15:04:05 form and args
+// wrapped with Redact shown as [REDACTED]. Placeholders
+// inside quoted strings are left alone, as are placeholders without a matching arg.
+//
+// Interpolate is for debugging only: the result must never be executed in place of the
+// query and its args, since its quoting does not follow every database's rules.
+func Interpolate(query string, args ...any) string {
+	return Default().Interpolate(query, args...)
+}
+
+// Interpolate renders query with its placeholders replaced by args, rendered with the write options of c.
+func (c *Config) Interpolate(query string, args ...any) string {
+	var b strings.Builder
+	next := 0
+	var quote rune
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '?' && next < len(args):
+			b.WriteString(c.literal(args[next]))
+			next++
+			continue
+		case r == '$' && i+1 < len(runes) && runes[i+1] >= '0' && runes[i+1] <= '9':
+			j := i + 1
+			for j < len(runes) && runes[j] >= '0' && runes[j] <= '9' {
+				j++
+			}
+			if n, err := strconv.Atoi(string(runes[i+1 : j])); err == nil && n >= 1 && n <= len(args) {
+				b.WriteString(c.literal(args[n-1]))
+				i = j - 1
+				continue
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// literal renders v as a SQL literal.
+func (c *Config) literal(v any) string {
+	if _, ok := v.(redacted); ok {
+		return "[REDACTED]"
+	}
+	v, err := c.driverValue(v)
+	if err == nil {
+		v, err = driver.DefaultParameterConverter.ConvertValue(v)
+	}
+	if err != nil {
+		return fmt.Sprintf("NULL /* %v */", err)
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case []byte:
+		return "X'" + hex.EncodeToString(v) + "'"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		return "'" + v.Format(bulkTimeLayout) + "'"
+	}
+	return fmt.Sprintf("'%v'", v)
+}
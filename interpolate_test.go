@@ -0,0 +1,30 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolate(t *testing.T) {
+	at := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	query := sqlnull.Interpolate("UPDATE users SET name = ?, phone = ?, email = ?, verified_at = ?, active = ?, avatar = ? WHERE id = ? AND note <> '?'",
+		"O'Brien", (*string)(nil), sql.NullString{}, &at, true, []byte{0xca, 0xfe}, 1)
+	require.Equal(t, "UPDATE users SET name = 'O''Brien', phone = NULL, email = NULL, verified_at = '2024-08-01 10:00:00', active = TRUE, avatar = X'cafe' WHERE id = 1 AND note <> '?'", query)
+
+	query = sqlnull.Interpolate("SELECT * FROM users WHERE id = $2 AND score > $1 AND name = $3", 1.5, int32(7))
+	require.Equal(t, "SELECT * FROM users WHERE id = 7 AND score > 1.5 AND name = $3", query)
+
+	_, args, err := sqlnull.Insert("users", &struct {
+		ID    int64
+		Phone *string
+	}{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO users (id, phone) VALUES (1, NULL)", sqlnull.Interpolate("INSERT INTO users (id, phone) VALUES (?, ?)", args...))
+}
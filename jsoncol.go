@@ -0,0 +1,50 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// JSON returns a scanner decoding a JSON document stored in a TEXT, JSON or JSONB column
+// into the value dst points to, such as a map[string]any, a struct or a type implementing
+// json.Unmarshaler. NULL stores the zero value, so a pointer to pointer is left nil.
+// Drivers returning the document already decoded are handled by encoding it again.
+// ScanStruct does the same for fields tagged with the json option: `db:"settings,json"`.
+//
+//	var settings *Settings
+//	err = row.Scan(&id, sqlnull.JSON(&settings))
+func JSON(dst any) sql.Scanner {
+	return scanFunc(func(src any) error {
+		val := reflect.ValueOf(dst)
+		if val.Kind() != reflect.Ptr || val.IsNil() {
+			return fmt.Errorf("JSON destination must be a non-nil pointer, got %T", dst)
+		}
+		return scanJSON(val.Elem(), src)
+	})
+}
+
+// scanJSON stores the JSON document src into dst.
+func scanJSON(dst reflect.Value, src any) error {
+	dst.SetZero()
+	if src == nil {
+		return nil
+	}
+
+	var data []byte
+	switch v := src.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(data, dst.Addr().Interface())
+}
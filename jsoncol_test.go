@@ -0,0 +1,79 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type Preferences struct {
+	Theme  string `json:"theme"`
+	Alerts *bool  `json:"alerts"`
+}
+
+type Tags []string
+
+func (t *Tags) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*t = strings.Split(s, ",")
+	return nil
+}
+
+func TestJSON(t *testing.T) {
+	var prefs *Preferences
+	require.NoError(t, sqlnull.JSON(&prefs).Scan(`{"theme":"dark","alerts":null}`))
+	require.Equal(t, &Preferences{Theme: "dark"}, prefs)
+
+	require.NoError(t, sqlnull.JSON(&prefs).Scan(nil))
+	require.Nil(t, prefs)
+
+	doc := map[string]any{"stale": true}
+	require.NoError(t, sqlnull.JSON(&doc).Scan([]byte(`{"a":1}`)))
+	require.Equal(t, map[string]any{"a": 1.0}, doc)
+
+	// already decoded by the driver
+	require.NoError(t, sqlnull.JSON(&doc).Scan(map[string]any{"b": "x"}))
+	require.Equal(t, map[string]any{"b": "x"}, doc)
+
+	var tags Tags
+	require.NoError(t, sqlnull.JSON(&tags).Scan(`"a,b"`))
+	require.Equal(t, Tags{"a", "b"}, tags)
+
+	require.Error(t, sqlnull.JSON(&prefs).Scan("{"))
+	require.ErrorContains(t, sqlnull.JSON(prefs).Scan("{}"), "non-nil pointer")
+}
+
+func TestJSONTag(t *testing.T) {
+	db := makeusers(t)
+
+	type Profile struct {
+		ID          int64          `db:"id"`
+		Preferences *Preferences   `db:"settings,json"`
+		Extra       map[string]any `db:"extra,json"`
+	}
+
+	rows, err := db.Query(`SELECT id, CASE id WHEN 1 THEN '{"theme":"light","alerts":true}' END AS settings, '{"n":2}' AS extra FROM users ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var profiles []Profile
+	for rows.Next() {
+		var p Profile
+		require.NoError(t, sqlnull.ScanStruct(rows, &p))
+		profiles = append(profiles, p)
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, profiles, 2)
+	require.Equal(t, "light", profiles[0].Preferences.Theme)
+	require.True(t, *profiles[0].Preferences.Alerts)
+	require.Nil(t, profiles[1].Preferences)
+	require.Equal(t, map[string]any{"n": 2.0}, profiles[1].Extra)
+}
@@ -0,0 +1,42 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import "bytes"
+
+// Lazy is a scan destination that keeps the raw driver value and only converts
+// it to T when Get is called. It suits wide rows where most columns are never read.
+//
+// T may be any type the package can scan into through a pointer, e.g. Lazy[*string]
+// yields nil for NULL while Lazy[string] yields the zero value.
+type Lazy[T any] struct {
+	raw   any
+	valid bool
+}
+
+// Scan implements the sql.Scanner interface for Lazy. Byte slices are copied,
+// since the driver may reuse their buffer for the next row.
+func (l *Lazy[T]) Scan(src any) error {
+	if b, ok := src.([]byte); ok {
+		src = bytes.Clone(b)
+	}
+	l.raw, l.valid = src, src != nil
+	return nil
+}
+
+// Get converts the raw value to T, returning any conversion error.
+func (l *Lazy[T]) Get() (T, error) {
+	var v T
+	err := Default().scan(&v, l.raw)
+	return v, err
+}
+
+// Raw returns the raw driver value as it was scanned.
+func (l *Lazy[T]) Raw() any {
+	return l.raw
+}
+
+// Valid reports whether the scanned value was not NULL.
+func (l *Lazy[T]) Valid() bool {
+	return l.valid
+}
@@ -0,0 +1,62 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazy(t *testing.T) {
+	var name sqlnull.Lazy[*string]
+	buf := []byte("lorem ipsum")
+	require.NoError(t, name.Scan(buf))
+	copy(buf, "xxxxx")
+	require.True(t, name.Valid())
+
+	v, err := name.Get()
+	require.NoError(t, err)
+	require.Equal(t, "lorem ipsum", *v)
+
+	var count sqlnull.Lazy[int64]
+	require.NoError(t, count.Scan(nil))
+	require.False(t, count.Valid())
+	n, err := count.Get()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	require.NoError(t, count.Scan("not a number"))
+	require.Equal(t, "not a number", count.Raw())
+	_, err = count.Get()
+	require.Error(t, err)
+}
+
+func TestLazyScanStruct(t *testing.T) {
+	db := makeusers(t)
+
+	type LazyUser struct {
+		ID       int64
+		LastName sqlnull.Lazy[*string]
+	}
+
+	rows, err := db.Query("SELECT id, last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var users []LazyUser
+	for rows.Next() {
+		var user LazyUser
+		require.NoError(t, sqlnull.ScanStruct(rows, &user))
+		users = append(users, user)
+	}
+	require.Len(t, users, 2)
+
+	last, err := users[0].LastName.Get()
+	require.NoError(t, err)
+	require.Equal(t, "doe", *last)
+	last, err = users[1].LastName.Get()
+	require.NoError(t, err)
+	require.Nil(t, last)
+}
@@ -0,0 +1,63 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTooLarge is returned when a TEXT or BLOB value exceeds the limit set with WithMaxBytes.
+var ErrTooLarge = errors.New("value exceeds byte limit")
+
+// WithMaxBytes limits string and []byte column values to n bytes. Larger values fail
+// to scan with an error wrapping ErrTooLarge, protecting services from a single
+// pathological row being copied into targets. The limit applies to targets wrapped
+// by the package; those handed to database/sql untouched are not checked.
+func WithMaxBytes(n int) Option {
+	return func(c *Config) {
+		c.maxBytes = n
+		c.truncateBytes = false
+	}
+}
+
+// WithTruncateBytes limits string and []byte column values to n bytes like WithMaxBytes,
+// but cuts larger values down to n bytes instead of failing. Truncated columns are
+// flagged in the Report returned by ScanReport and ScanStructReport.
+func WithTruncateBytes(n int) Option {
+	return func(c *Config) {
+		c.maxBytes = n
+		c.truncateBytes = true
+	}
+}
+
+// limit applies the byte limit to src, reporting whether it was truncated.
+func (c *Config) limit(src any) (any, bool, error) {
+	if c.maxBytes <= 0 {
+		return src, false, nil
+	}
+
+	var size int
+	switch s := src.(type) {
+	case string:
+		size = len(s)
+	case []byte:
+		size = len(s)
+	default:
+		return src, false, nil
+	}
+	if size <= c.maxBytes {
+		return src, false, nil
+	}
+	if !c.truncateBytes {
+		return nil, false, fmt.Errorf("%w: %d bytes, limit is %d", ErrTooLarge, size, c.maxBytes)
+	}
+
+	switch s := src.(type) {
+	case string:
+		return s[:c.maxBytes], true, nil
+	case []byte:
+		return s[:c.maxBytes], true, nil
+	}
+	return src, false, nil
+}
@@ -0,0 +1,57 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxBytes(t *testing.T) {
+	config := sqlnull.NewConfig(sqlnull.WithMaxBytes(8))
+
+	var name *string
+	require.NoError(t, config.New(&name).Scan("lorem"))
+	require.Equal(t, "lorem", *name)
+
+	err := config.New(&name).Scan(strings.Repeat("x", 9))
+	require.True(t, errors.Is(err, sqlnull.ErrTooLarge))
+	require.Equal(t, "lorem", *name)
+
+	var raw any
+	err = config.Tee(&raw).Scan([]byte("123456789"))
+	require.Error(t, err)
+
+	var count *int64
+	require.NoError(t, config.New(&count).Scan(int64(1234567890123)))
+}
+
+func TestTruncateBytes(t *testing.T) {
+	config := sqlnull.NewConfig(sqlnull.WithTruncateBytes(5))
+
+	var name *string
+	require.NoError(t, config.New(&name).Scan("lorem ipsum"))
+	require.Equal(t, "lorem", *name)
+
+	db := makeusers(t)
+	rows, err := db.Query("SELECT id, first_name, 'lorem ipsum' AS last_name FROM users WHERE id=1")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	var user struct {
+		ID        int64
+		FirstName *string
+		LastName  *string
+	}
+	report, err := config.ScanStructReport(rows, &user)
+	require.NoError(t, err)
+	require.Equal(t, "john", *user.FirstName)
+	require.False(t, report[1].Truncated)
+	require.Equal(t, "lorem", *user.LastName)
+	require.True(t, report[2].Truncated)
+}
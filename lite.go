@@ -0,0 +1,62 @@
+//go:build sqlnull_lite
+
+// Building with the sqlnull_lite tag leaves out everything relying on reflect, for TinyGo and
+// WASM builds where reflect support is limited and binary size matters. What remains are the
+// typed constructors TargetOf, ValueOf and ArgOf, the pointer helpers such as Ptr and Deref,
+// and a Target and Scanner recognising the common target types with a type switch.
+
+package sqlnull
+
+import "time"
+
+// Target returns a scanner for a pointer to pointer of a common type, such as **string,
+// **int64 or **time.Time, otherwise returns the target itself. Unlike the full build it
+// applies no Config and leaves other types, such as structs and slices, to database/sql.
+func Target(target any) any {
+	switch t := target.(type) {
+	case nil:
+		return new(any)
+	case **string:
+		return TargetOf(t)
+	case **[]byte:
+		return TargetOf(t)
+	case **bool:
+		return TargetOf(t)
+	case **int:
+		return TargetOf(t)
+	case **int8:
+		return TargetOf(t)
+	case **int16:
+		return TargetOf(t)
+	case **int32:
+		return TargetOf(t)
+	case **int64:
+		return TargetOf(t)
+	case **uint:
+		return TargetOf(t)
+	case **uint8:
+		return TargetOf(t)
+	case **uint16:
+		return TargetOf(t)
+	case **uint32:
+		return TargetOf(t)
+	case **uint64:
+		return TargetOf(t)
+	case **float32:
+		return TargetOf(t)
+	case **float64:
+		return TargetOf(t)
+	case **time.Time:
+		return TargetOf(t)
+	}
+	return target
+}
+
+// Scanner wraps multiple targets with Target.
+func Scanner(targets ...any) []any {
+	result := make([]any, len(targets))
+	for i, target := range targets {
+		result[i] = Target(target)
+	}
+	return result
+}
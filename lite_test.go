@@ -0,0 +1,34 @@
+//go:build sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiteScanner(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	var id int64
+	var name *string
+	var score *float64
+	at := sqlnull.Ptr(time.Now())
+	require.NoError(t, db.QueryRow("SELECT 7, 'john', 1.5, NULL").Scan(sqlnull.Scanner(&id, &name, &score, &at)...))
+	require.Equal(t, int64(7), id)
+	require.Equal(t, "john", *name)
+	require.Equal(t, 1.5, *score)
+	require.Nil(t, at)
+
+	require.NoError(t, db.QueryRow("SELECT NULL, 2").Scan(sqlnull.Scanner(&name, nil)...))
+	require.Nil(t, name)
+
+	var unknown *struct{ A int }
+	require.Same(t, &unknown, sqlnull.Target(&unknown))
+}
@@ -0,0 +1,125 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"log/slog"
+	"time"
+)
+
+// maxLoggedArg is the length beyond which logged args are shortened.
+const maxLoggedArg = 64
+
+// Redact wraps a statement arg so it is written to the database as is, but logged by LogDB
+// and rendered by Interpolate as [REDACTED]. Insert wraps the fields tagged with the
+// redact option, e.g. `db:"password_hash,redact"`, the same way.
+func Redact(v any) driver.Valuer {
+	return redacted{v: v}
+}
+
+// redacted implements Redact.
+type redacted struct {
+	v any
+}
+
+// Value implements the driver.Valuer interface for redacted.
+func (r redacted) Value() (driver.Value, error) {
+	v, err := Default().driverValue(r.v)
+	if err != nil {
+		return nil, err
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+// Queryer is the part of *sql.DB, *sql.Tx and *sql.Conn wrapped by LogDB.
+type Queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// LogDB wraps a Queryer, logging every statement with its duration and a summary of its
+// args: NULL args, nil pointers included, are shown as NULL, long values are shortened
+// and args wrapped with Redact are hidden. Statements are logged at debug level, and
+// failed ones at error level.
+//
+//	db := sqlnull.NewLogDB(sqldb, slog.Default())
+//	_, err := db.ExecContext(ctx, "UPDATE users SET phone = ?, password_hash = ? WHERE id = ?",
+//		user.Phone, sqlnull.Redact(hash), user.ID)
+type LogDB struct {
+	db     Queryer
+	logger *slog.Logger
+	config *Config
+}
+
+// NewLogDB returns a LogDB running statements on db and logging them to logger.
+func NewLogDB(db Queryer, logger *slog.Logger) *LogDB {
+	return Default().NewLogDB(db, logger)
+}
+
+// NewLogDB returns a LogDB running statements on db and logging them to logger, summarizing args with the write options of c.
+func (c *Config) NewLogDB(db Queryer, logger *slog.Logger) *LogDB {
+	return &LogDB{db: db, logger: logger, config: c}
+}
+
+// ExecContext runs and logs a statement returning no rows.
+func (l *LogDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	result, err := l.db.ExecContext(ctx, query, args...)
+	l.log(ctx, "sql exec", query, args, start, err)
+	return result, err
+}
+
+// QueryContext runs and logs a query returning rows.
+func (l *LogDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := l.db.QueryContext(ctx, query, args...)
+	l.log(ctx, "sql query", query, args, start, err)
+	return rows, err
+}
+
+// QueryRowContext runs and logs a query returning at most one row. Its error is only
+// known once the row is scanned, so it is not logged.
+func (l *LogDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := l.db.QueryRowContext(ctx, query, args...)
+	l.log(ctx, "sql query", query, args, start, nil)
+	return row
+}
+
+// log writes a record for a statement.
+func (l *LogDB) log(ctx context.Context, msg, query string, args []any, start time.Time, err error) {
+	level := slog.LevelDebug
+	if err != nil {
+		level = slog.LevelError
+	}
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	summary := make([]string, len(args))
+	for i, arg := range args {
+		summary[i] = l.config.summary(arg)
+	}
+	attrs := []slog.Attr{
+		slog.String("query", query),
+		slog.Any("args", summary),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// summary renders an arg for logging.
+func (c *Config) summary(v any) string {
+	s := []rune(c.literal(v))
+	if len(s) > maxLoggedArg {
+		return string(s[:maxLoggedArg]) + "..."
+	}
+	return string(s)
+}
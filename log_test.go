@@ -0,0 +1,64 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogDB(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	db := sqlnull.NewLogDB(makeusers(t), logger)
+	ctx := context.Background()
+
+	_, err := db.ExecContext(ctx, "UPDATE users SET last_name = ?, first_name = ? WHERE id = ?",
+		(*string)(nil), sqlnull.Redact("secret"), 1)
+	require.NoError(t, err)
+
+	var first string
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT first_name FROM users WHERE id = ?", 1).Scan(&first))
+	require.Equal(t, "secret", first)
+
+	_, err = db.QueryContext(ctx, "SELECT nope FROM users WHERE last_name = ?", strings.Repeat("x", 100))
+	require.Error(t, err)
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 3)
+
+	require.Equal(t, "sql exec", records[0]["msg"])
+	require.Equal(t, "DEBUG", records[0]["level"])
+	require.Equal(t, []any{"NULL", "[REDACTED]", "1"}, records[0]["args"])
+
+	require.Equal(t, "sql query", records[1]["msg"])
+	require.Equal(t, "ERROR", records[2]["level"])
+	require.Contains(t, records[2]["error"], "no such column")
+	require.Len(t, records[2]["args"].([]any)[0], 67)
+}
+
+func TestInsertRedact(t *testing.T) {
+	query, args, err := sqlnull.Insert("users", &struct {
+		ID       int64
+		Password string `db:"password_hash,redact"`
+	}{ID: 1, Password: "hash"})
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO users (id, password_hash) VALUES (1, [REDACTED])", sqlnull.Interpolate(query, args...))
+
+	v, err := args[1].(driver.Valuer).Value()
+	require.NoError(t, err)
+	require.Equal(t, "hash", v)
+}
@@ -0,0 +1,91 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"reflect"
+)
+
+// MapScan scans the current row of rows into a map keyed by column name, for dynamic or
+// reporting queries whose columns are not known in advance:
+//
+//	for rows.Next() {
+//		row, err := sqlnull.MapScan(rows)
+//		fmt.Println(row["id"], row["phone"]) // 42 <nil>
+//	}
+//
+// NULL columns hold nil. Other values are converted to the scan type the driver reports
+// for the column, with sql.Null* types unwrapped, so an INTEGER column a MySQL driver
+// returns as text still comes back as an int64. Columns without a usable scan type keep
+// the driver value, as do byte slice scan types such as sql.RawBytes. A later column replaces an earlier one with the same name.
+func MapScan(rows *sql.Rows) (map[string]any, error) {
+	return Default().MapScan(rows)
+}
+
+// MapScan scans the current row of rows into a map keyed by column name, converting with c.
+func (c *Config) MapScan(rows *sql.Rows) (map[string]any, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		columnTypes = nil
+	}
+
+	values := make([]any, len(columns))
+	targets := make([]any, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]any, len(columns))
+	for i, column := range columns {
+		var scanType reflect.Type
+		if i < len(columnTypes) {
+			scanType = columnTypes[i].ScanType()
+		}
+		v, err := c.mapValue(values[i], scanType)
+		if err != nil {
+			return nil, &ColumnError{Index: i, Column: column, Err: err}
+		}
+		result[column] = v
+	}
+	return result, nil
+}
+
+// mapValue converts the driver value src to scanType for MapScan.
+func (c *Config) mapValue(src any, scanType reflect.Type) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+	if scanType != nil && isNullWrapper(scanType) {
+		scanType = scanType.Field(0).Type
+	}
+	if scanType == nil || scanType.Kind() == reflect.Interface ||
+		scanType.Kind() == reflect.Slice && scanType.Elem().Kind() == reflect.Uint8 {
+		return c.rawValue(src)
+	}
+	if _, err := c.converter(reflect.PointerTo(reflect.PointerTo(scanType))); err != nil {
+		return c.rawValue(src)
+	}
+
+	ptr := reflect.New(reflect.PointerTo(scanType))
+	if err := c.New(ptr.Interface()).Scan(src); err != nil {
+		return nil, err
+	}
+	return ptr.Elem().Elem().Interface(), nil
+}
+
+// rawValue applies the byte limit and time options of c to the driver value src.
+func (c *Config) rawValue(src any) (any, error) {
+	src, _, err := c.limit(src)
+	if err != nil {
+		return nil, err
+	}
+	return c.finish(src), nil
+}
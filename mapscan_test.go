@@ -0,0 +1,44 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapScan(t *testing.T) {
+	db := makeusers(t)
+
+	rows, err := db.Query("SELECT id, first_name, last_name, 1.5 AS ratio FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var result []map[string]any
+	for rows.Next() {
+		row, err := sqlnull.MapScan(rows)
+		require.NoError(t, err)
+		result = append(result, row)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []map[string]any{
+		{"id": int64(1), "first_name": "john", "last_name": "doe", "ratio": 1.5},
+		{"id": int64(2), "first_name": "jane", "last_name": nil, "ratio": 1.5},
+	}, result)
+}
+
+func TestMapScanLimit(t *testing.T) {
+	db := makeusers(t)
+
+	rows, err := db.Query("SELECT id, first_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	_, err = sqlnull.NewConfig(sqlnull.WithMaxBytes(2)).MapScan(rows)
+	var ce *sqlnull.ColumnError
+	require.ErrorAs(t, err, &ce)
+	require.Equal(t, "first_name", ce.Column)
+}
@@ -0,0 +1,51 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import "database/sql"
+
+// Matrix reads the remaining rows of rows into a slice of rows, each holding the column
+// values as returned by the driver, e.g. int64, float64, string, []byte or time.Time,
+// and nil for NULL. It is a building block for table widgets, exports and ad-hoc tooling
+// that do not know the result shape in advance. rows is always closed on return.
+//
+//	cols, data, err := sqlnull.Matrix(rows)
+//	for _, row := range data {
+//		fmt.Println(row...)
+//	}
+func Matrix(rows *sql.Rows) ([]string, [][]any, error) {
+	return Default().Matrix(rows)
+}
+
+// Matrix reads the remaining rows of rows, applying the byte limit and time options of c to each value.
+func (c *Config) Matrix(rows *sql.Rows) ([]string, [][]any, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var data [][]any
+	for rows.Next() {
+		values := make([]any, len(columns))
+		targets := make([]any, len(columns))
+		for i := range values {
+			targets[i] = &values[i]
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return nil, nil, err
+		}
+		for i, v := range values {
+			if v, _, err = c.limit(v); err != nil {
+				return nil, nil, &ColumnError{Index: i, Column: columns[i], Err: err}
+			}
+			values[i] = c.finish(v)
+		}
+		data = append(data, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return columns, data, rows.Close()
+}
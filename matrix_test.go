@@ -0,0 +1,30 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrix(t *testing.T) {
+	db := makeusers(t)
+	rows, err := db.Query("SELECT id, first_name, last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+
+	cols, data, err := sqlnull.Matrix(rows)
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "first_name", "last_name"}, cols)
+	require.Equal(t, [][]any{
+		{int64(1), "john", "doe"},
+		{int64(2), "jane", nil},
+	}, data)
+
+	rows, err = db.Query("SELECT first_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	_, data, err = sqlnull.NewConfig(sqlnull.WithTruncateBytes(2)).Matrix(rows)
+	require.NoError(t, err)
+	require.Equal(t, [][]any{{"jo"}, {"ja"}}, data)
+}
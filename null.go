@@ -0,0 +1,67 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// Null holds a value of type T that may be NULL, for struct fields that should not be
+// pointers. Unlike sql.Null, it converts values like Scan does, so it works with every
+// type a pointer target accepts, including named types such as Null[Status] for
+// `type Status string`. NULL leaves Valid false and V the zero value of T.
+//
+//	type User struct {
+//		ID    int64
+//		Phone sqlnull.Null[string]
+//		Age   sqlnull.Null[int]
+//	}
+type Null[T any] struct {
+	V     T
+	Valid bool
+}
+
+// Scan implements the sql.Scanner interface for Null, converting src like Scan does.
+func (n *Null[T]) Scan(src any) error {
+	if err := Default().scan(&n.V, src); err != nil {
+		return err
+	}
+	n.Valid = src != nil
+	return nil
+}
+
+// Value implements the driver.Valuer interface for Null.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	v, err := Default().driverValue(n.V)
+	if err != nil {
+		return nil, err
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Null, encoding NULL as null.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.V)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Null.
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	n.V, n.Valid = zero, false
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.V); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
@@ -0,0 +1,57 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type nullStatus string
+
+func TestNull(t *testing.T) {
+	db := makeusers(t)
+
+	type User struct {
+		ID         int64
+		FirstName  sqlnull.Null[nullStatus] `db:"first_name"`
+		LastName   sqlnull.Null[string]     `db:"last_name"`
+		VerifiedAt sqlnull.Null[int32]      `db:"verified_at"`
+	}
+
+	rows, err := db.Query("SELECT id, first_name, last_name, id * 10 AS verified_at FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var got []User
+	for rows.Next() {
+		var user User
+		require.NoError(t, sqlnull.ScanStruct(rows, &user))
+		got = append(got, user)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []User{
+		{ID: 1, FirstName: sqlnull.Null[nullStatus]{V: "john", Valid: true}, LastName: sqlnull.Null[string]{V: "doe", Valid: true}, VerifiedAt: sqlnull.Null[int32]{V: 10, Valid: true}},
+		{ID: 2, FirstName: sqlnull.Null[nullStatus]{V: "jane", Valid: true}, VerifiedAt: sqlnull.Null[int32]{V: 20, Valid: true}},
+	}, got)
+
+	n := sqlnull.Null[int64]{V: 7, Valid: true}
+	require.NoError(t, n.Scan(nil))
+	require.Equal(t, sqlnull.Null[int64]{}, n)
+
+	var s sqlnull.Null[nullStatus]
+	require.NoError(t, db.QueryRow("SELECT ?", sqlnull.Null[nullStatus]{V: "active", Valid: true}).Scan(&s))
+	require.Equal(t, sqlnull.Null[nullStatus]{V: "active", Valid: true}, s)
+	require.NoError(t, db.QueryRow("SELECT ?", sqlnull.Null[nullStatus]{}).Scan(&s))
+	require.False(t, s.Valid)
+
+	data, err := json.Marshal([]sqlnull.Null[int]{{V: 1, Valid: true}, {}})
+	require.NoError(t, err)
+	require.JSONEq(t, `[1, null]`, string(data))
+	var decoded []sqlnull.Null[int]
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, []sqlnull.Null[int]{{V: 1, Valid: true}, {}}, decoded)
+}
@@ -0,0 +1,142 @@
+// Package nullarrow converts query results to Apache Arrow record batches, based on
+// github.com/apache/arrow-go, mapping SQL NULLs into Arrow validity bitmaps so
+// analytical consumers can ingest query output directly.
+package nullarrow
+
+import (
+	"database/sql"
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/ceebydith/sqlnull"
+)
+
+// column maps a result set column to an Arrow field.
+type column struct {
+	field  arrow.Field
+	target any // pointer to a nil-able pointer, scanned by sqlnull
+	append func(b array.Builder)
+}
+
+// Records reads the remaining rows of rows into Arrow record batches of up to batchSize rows,
+// calling fn for each batch. The record is released once fn returns, so fn must Retain it
+// to keep it. Columns are typed from rows.ColumnTypes and scanned with the conversions of
+// config, nil meaning the sqlnull defaults; NULLs are recorded in the validity bitmap of
+// their column, and columns the driver reports as NOT NULL become non-nullable fields.
+//
+//	err := nullarrow.Records(rows, 1024, nil, func(rec arrow.Record) error {
+//		return writer.Write(rec)
+//	})
+func Records(rows *sql.Rows, batchSize int, config *sqlnull.Config, fn func(arrow.Record) error) error {
+	if config == nil {
+		config = sqlnull.NewConfig()
+	}
+	if batchSize <= 0 {
+		batchSize = 1024
+	}
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	columns := make([]*column, len(types))
+	fields := make([]arrow.Field, len(types))
+	targets := make([]any, len(types))
+	for i, typ := range types {
+		columns[i] = newColumn(typ)
+		fields[i] = columns[i].field
+		targets[i] = columns[i].target
+	}
+	schema := arrow.NewSchema(fields, nil)
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+
+	flush := func() error {
+		rec := builder.NewRecord()
+		defer rec.Release()
+		return fn(rec)
+	}
+
+	count := 0
+	for rows.Next() {
+		if err := config.Scan(rows.Scan, targets...); err != nil {
+			return err
+		}
+		for i, col := range columns {
+			col.append(builder.Field(i))
+		}
+		if count++; count == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+			count = 0
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if count > 0 {
+		return flush()
+	}
+	return nil
+}
+
+// newColumn chooses the Arrow type and scan target for a column from its scan type.
+func newColumn(typ *sql.ColumnType) *column {
+	col := &column{field: arrow.Field{Name: typ.Name(), Nullable: true}}
+	if nullable, ok := typ.Nullable(); ok && !nullable {
+		col.field.Nullable = false
+	}
+
+	scanType := typ.ScanType()
+	if scanType != nil && scanType.Kind() == reflect.Struct && scanType.NumField() == 2 && scanType.Field(1).Name == "Valid" {
+		// sql.NullString and friends
+		scanType = scanType.Field(0).Type
+	}
+
+	var kind reflect.Kind
+	if scanType != nil {
+		kind = scanType.Kind()
+	}
+	switch {
+	case kind == reflect.Bool:
+		col.field.Type = arrow.FixedWidthTypes.Boolean
+		col.target, col.append = appender(func(b *array.BooleanBuilder, v bool) { b.Append(v) })
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		col.field.Type = arrow.PrimitiveTypes.Int64
+		col.target, col.append = appender(func(b *array.Int64Builder, v int64) { b.Append(v) })
+	case kind >= reflect.Uint && kind <= reflect.Uint64 && kind != reflect.Uint8:
+		col.field.Type = arrow.PrimitiveTypes.Uint64
+		col.target, col.append = appender(func(b *array.Uint64Builder, v uint64) { b.Append(v) })
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		col.field.Type = arrow.PrimitiveTypes.Float64
+		col.target, col.append = appender(func(b *array.Float64Builder, v float64) { b.Append(v) })
+	case scanType == reflect.TypeOf(time.Time{}):
+		col.field.Type = &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}
+		col.target, col.append = appender(func(b *array.TimestampBuilder, v time.Time) { b.Append(arrow.Timestamp(v.UnixNano())) })
+	case kind == reflect.Slice && scanType.Elem().Kind() == reflect.Uint8:
+		col.field.Type = arrow.BinaryTypes.Binary
+		col.target, col.append = appender(func(b *array.BinaryBuilder, v []byte) { b.Append(v) })
+	default:
+		col.field.Type = arrow.BinaryTypes.String
+		col.target, col.append = appender(func(b *array.StringBuilder, v string) { b.Append(v) })
+	}
+	return col
+}
+
+// appender returns a scan target of type **T and a function appending its current
+// value to a builder of type B, or a null when it is nil.
+func appender[B array.Builder, T any](add func(b B, v T)) (any, func(b array.Builder)) {
+	target := new(*T)
+	return target, func(b array.Builder) {
+		if *target == nil {
+			b.AppendNull()
+			return
+		}
+		add(b.(B), **target)
+	}
+}
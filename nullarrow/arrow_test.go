@@ -0,0 +1,66 @@
+package nullarrow_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/ceebydith/sqlnull/nullarrow"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecords(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`
+		CREATE TABLE users (id INTEGER NOT NULL, username TEXT, score REAL, active BOOLEAN, verified_at DATETIME, avatar BLOB);
+		INSERT INTO users VALUES
+			(1, 'johndoe', 1.5, 1, '2024-08-01 10:00:00', x'0102'),
+			(2, NULL, NULL, NULL, NULL, NULL),
+			(3, 'jane', 2.5, 0, NULL, NULL);
+	`)
+	require.NoError(t, err)
+
+	rows, err := db.Query("SELECT id, username, score, active, verified_at, avatar FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var sizes []int64
+	var first arrow.Record
+	err = nullarrow.Records(rows, 2, nil, func(rec arrow.Record) error {
+		sizes = append(sizes, rec.NumRows())
+		if first == nil {
+			rec.Retain()
+			first = rec
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{2, 1}, sizes)
+	defer first.Release()
+
+	require.Equal(t, 6, int(first.NumCols()))
+	require.Equal(t, []int64{1, 2}, first.Column(0).(*array.Int64).Int64Values())
+
+	username := first.Column(1).(*array.String)
+	require.Equal(t, "johndoe", username.Value(0))
+	require.True(t, username.IsNull(1))
+	require.Equal(t, 1, username.NullN())
+
+	require.Equal(t, 1.5, first.Column(2).(*array.Float64).Value(0))
+	require.True(t, first.Column(3).(*array.Boolean).Value(0))
+	require.True(t, first.Column(3).IsNull(1))
+
+	verifiedAt := first.Column(4).(*array.Timestamp)
+	require.Equal(t, time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC).UnixNano(), int64(verifiedAt.Value(0)))
+	require.True(t, verifiedAt.IsNull(1))
+
+	require.Equal(t, []byte{1, 2}, first.Column(5).(*array.Binary).Value(0))
+	require.True(t, first.Column(5).IsNull(1))
+}
@@ -0,0 +1,21 @@
+// Package nullcbor registers a CBOR codec, based on github.com/fxamacker/cbor/v2, for the
+// nullable wrappers of sqlnull. Import it for its side effect:
+//
+//	import _ "github.com/ceebydith/sqlnull/nullcbor"
+//
+// Once imported, sqlnull.Optional and sqlnull.Gob values implement cbor.Marshaler and
+// cbor.Unmarshaler, encoding NULL as CBOR null, so database models can be exchanged
+// as CBOR payloads directly.
+package nullcbor
+
+import (
+	"github.com/ceebydith/sqlnull"
+	"github.com/fxamacker/cbor/v2"
+)
+
+func init() {
+	sqlnull.RegisterCodec("cbor", sqlnull.Codec{
+		Marshal:   cbor.Marshal,
+		Unmarshal: cbor.Unmarshal,
+	})
+}
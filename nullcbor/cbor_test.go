@@ -0,0 +1,43 @@
+package nullcbor_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	_ "github.com/ceebydith/sqlnull/nullcbor"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type Reading struct {
+	Sensor  string
+	Value   sqlnull.Optional[float64]
+	Unit    sqlnull.Optional[string]
+	Payload sqlnull.Gob[[]int]
+}
+
+func TestCBOR(t *testing.T) {
+	reading := Reading{
+		Sensor:  "t1",
+		Value:   sqlnull.Some(21.5),
+		Unit:    sqlnull.Optional[string]{Set: true},
+		Payload: sqlnull.Gob[[]int]{V: []int{1, 2}, Valid: true},
+	}
+
+	b, err := cbor.Marshal(reading)
+	require.NoError(t, err)
+
+	// NULL is encoded as CBOR null
+	var generic map[string]any
+	require.NoError(t, cbor.Unmarshal(b, &generic))
+	require.Equal(t, 21.5, generic["Value"])
+	require.Contains(t, generic, "Unit")
+	require.Nil(t, generic["Unit"])
+
+	var decoded Reading
+	require.NoError(t, cbor.Unmarshal(b, &decoded))
+	require.Equal(t, reading.Sensor, decoded.Sensor)
+	require.Equal(t, reading.Value, decoded.Value)
+	require.True(t, decoded.Unit.IsNull())
+	require.Equal(t, reading.Payload, decoded.Payload)
+}
@@ -0,0 +1,16 @@
+module github.com/ceebydith/sqlnull/nullmsgpack
+
+go 1.23.3
+
+require (
+	github.com/ceebydith/sqlnull v0.0.0-20261015040039-9285dc7f2c41
+	github.com/stretchr/testify v1.9.0
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
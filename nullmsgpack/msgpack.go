@@ -0,0 +1,21 @@
+// Package nullmsgpack registers a msgpack codec, based on github.com/vmihailenco/msgpack/v5,
+// for the nullable wrappers of sqlnull. Import it for its side effect:
+//
+//	import _ "github.com/ceebydith/sqlnull/nullmsgpack"
+//
+// Once imported, sqlnull.Optional and sqlnull.Gob values implement msgpack.Marshaler and
+// msgpack.Unmarshaler, encoding NULL as nil, so database models can be published on a
+// message queue as they are.
+package nullmsgpack
+
+import (
+	"github.com/ceebydith/sqlnull"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func init() {
+	sqlnull.RegisterCodec("msgpack", sqlnull.Codec{
+		Marshal:   msgpack.Marshal,
+		Unmarshal: msgpack.Unmarshal,
+	})
+}
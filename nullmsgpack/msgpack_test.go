@@ -0,0 +1,47 @@
+package nullmsgpack_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	_ "github.com/ceebydith/sqlnull/nullmsgpack"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type Event struct {
+	ID      int64
+	Name    sqlnull.Optional[string]
+	Phone   sqlnull.Optional[string]
+	At      sqlnull.Optional[time.Time]
+	Payload sqlnull.Gob[[]int]
+}
+
+func TestMsgpack(t *testing.T) {
+	at := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	event := Event{
+		ID:      1,
+		Name:    sqlnull.Some("signup"),
+		At:      sqlnull.Some(at),
+		Payload: sqlnull.Gob[[]int]{V: []int{1, 2}, Valid: true},
+	}
+
+	b, err := msgpack.Marshal(event)
+	require.NoError(t, err)
+
+	// NULL is encoded as nil
+	var generic map[string]any
+	require.NoError(t, msgpack.Unmarshal(b, &generic))
+	require.Equal(t, "signup", generic["Name"])
+	require.Contains(t, generic, "Phone")
+	require.Nil(t, generic["Phone"])
+
+	var decoded Event
+	require.NoError(t, msgpack.Unmarshal(b, &decoded))
+	require.Equal(t, int64(1), decoded.ID)
+	require.Equal(t, sqlnull.Some("signup"), decoded.Name)
+	require.False(t, decoded.Phone.Valid)
+	require.True(t, decoded.At.V.Equal(at))
+	require.Equal(t, event.Payload, decoded.Payload)
+}
@@ -0,0 +1,24 @@
+module github.com/ceebydith/sqlnull/nullparquet
+
+go 1.23.3
+
+require (
+	github.com/ceebydith/sqlnull v0.0.0-20261015040039-9285dc7f2c41
+	github.com/mattn/go-sqlite3 v1.14.24
+	github.com/parquet-go/parquet-go v0.25.0
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/andybalholm/brotli v1.1.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/klauspost/compress v1.17.9 // indirect
+	github.com/mattn/go-runewidth v0.0.15 // indirect
+	github.com/olekukonko/tablewriter v0.0.5 // indirect
+	github.com/pierrec/lz4/v4 v4.1.21 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/rivo/uniseg v0.4.7 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,73 @@
+package nullparquet_test
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/ceebydith/sqlnull/nullparquet"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+)
+
+type User struct {
+	ID         int64      `parquet:"id,optional"`
+	Username   *string    `parquet:"username,optional"`
+	Score      *float64   `parquet:"score,optional"`
+	Active     *bool      `parquet:"active,optional"`
+	VerifiedAt *time.Time `parquet:"verified_at,optional"`
+	Avatar     []byte     `parquet:"avatar,optional"`
+}
+
+func TestWriteRows(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`
+		CREATE TABLE users (id INTEGER NOT NULL, username TEXT, score REAL, active BOOLEAN, verified_at DATETIME, avatar BLOB);
+		INSERT INTO users VALUES (1, 'johndoe', 1.5, 1, '2024-08-01 10:00:00', x'0102'), (2, NULL, NULL, NULL, NULL, NULL);
+	`)
+	require.NoError(t, err)
+
+	rows, err := db.Query("SELECT id, username, score, active, verified_at, avatar FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var buf bytes.Buffer
+	n, err := nullparquet.WriteRows(&buf, rows, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	users, err := parquet.Read[User](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, []User{
+		{
+			ID:         1,
+			Username:   sqlnull.Ptr("johndoe"),
+			Score:      sqlnull.Ptr(1.5),
+			Active:     sqlnull.Ptr(true),
+			VerifiedAt: sqlnull.Ptr(time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)),
+			Avatar:     []byte{1, 2},
+		},
+		{ID: 2},
+	}, users)
+}
+
+func TestWriteStructs(t *testing.T) {
+	type Event struct {
+		ID   int64   `parquet:"id"`
+		Note *string `parquet:"note"`
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, nullparquet.WriteStructs(&buf, []Event{{ID: 1, Note: sqlnull.Ptr("lorem")}, {ID: 2}}))
+
+	events, err := parquet.Read[Event](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, []Event{{ID: 1, Note: sqlnull.Ptr("lorem")}, {ID: 2}}, events)
+}
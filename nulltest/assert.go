@@ -0,0 +1,180 @@
+//go:build !sqlnull_lite
+
+package nulltest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+)
+
+// TestingT is the part of *testing.T used by the assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertEqual reports an error when want and got differ. Pointers, sql.Null wrappers and
+// sqlnull.Optional are compared by the value they hold, and NULL however represented, so
+// a plain "555" equals a *string or sql.NullString holding "555". Values are compared like
+// sqlnull.Hash does. The failure lists every differing field with readable values instead
+// of pointer addresses:
+//
+//	nulltest.AssertEqual(t, User{ID: 1, Phone: sqlnull.Ptr("555")}, got)
+//	// not equal:
+//	//   Phone: want "555", got NULL
+func AssertEqual(t TestingT, want, got any) bool {
+	t.Helper()
+	if sqlnull.Hash(want) == sqlnull.Hash(got) {
+		return true
+	}
+
+	var lines []string
+	diffValues("", reflect.ValueOf(want), reflect.ValueOf(got), &lines)
+	t.Errorf("not equal:\n  %s", strings.Join(lines, "\n  "))
+	return false
+}
+
+// AssertNull reports an error unless v is NULL: nil, a nil pointer, an invalid sql.Null
+// wrapper or an Optional without a value.
+func AssertNull(t TestingT, v any) bool {
+	t.Helper()
+	if val, null := unwrap(reflect.ValueOf(v)); !null {
+		t.Errorf("expected NULL, got %s", render(val))
+		return false
+	}
+	return true
+}
+
+// AssertValid reports an error when v is NULL, see AssertNull.
+func AssertValid(t TestingT, v any) bool {
+	t.Helper()
+	if _, null := unwrap(reflect.ValueOf(v)); null {
+		t.Errorf("expected a value, got NULL for %T", v)
+		return false
+	}
+	return true
+}
+
+var optionalPkgPath = reflect.TypeOf(sqlnull.Optional[int]{}).PkgPath()
+
+// unwrap follows pointers and nullable wrappers down to the value they hold,
+// reporting whether that value is NULL.
+func unwrap(v reflect.Value) (reflect.Value, bool) {
+	for {
+		if !v.IsValid() {
+			return v, true
+		}
+		switch {
+		case v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface:
+			if v.IsNil() {
+				return v, true
+			}
+			v = v.Elem()
+		case v.Kind() == reflect.Struct && v.NumField() == 2 && v.Type().Field(1).Name == "Valid" && v.Field(1).Kind() == reflect.Bool:
+			if !v.Field(1).Bool() {
+				return v, true
+			}
+			v = v.Field(0)
+		case v.Kind() == reflect.Struct && strings.HasPrefix(v.Type().Name(), "Optional[") && v.Type().PkgPath() == optionalPkgPath:
+			if !v.FieldByName("Valid").Bool() {
+				return v, true
+			}
+			v = v.FieldByName("V")
+		default:
+			return v, false
+		}
+	}
+}
+
+// diffValues appends a line to lines for every difference between want and got below path.
+func diffValues(path string, want, got reflect.Value, lines *[]string) {
+	if hashOf(want) == hashOf(got) {
+		return
+	}
+	want, wantNull := unwrap(want)
+	got, gotNull := unwrap(got)
+
+	switch {
+	case wantNull || gotNull:
+	case want.Kind() == reflect.Struct && got.Kind() == reflect.Struct && want.Type() != timeType && got.Type() != timeType:
+		for i := 0; i < want.NumField(); i++ {
+			field := want.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if _, ok := got.Type().FieldByName(field.Name); !ok {
+				*lines = append(*lines, fmt.Sprintf("%s: want %s, got no such field", join(path, field.Name), render(want.Field(i))))
+				continue
+			}
+			diffValues(join(path, field.Name), want.Field(i), got.FieldByName(field.Name), lines)
+		}
+		return
+	case isList(want) && isList(got) && want.Len() == got.Len():
+		for i := 0; i < want.Len(); i++ {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), want.Index(i), got.Index(i), lines)
+		}
+		return
+	}
+
+	if path == "" {
+		path = "value"
+	}
+	*lines = append(*lines, fmt.Sprintf("%s: want %s, got %s", path, render(want), render(got)))
+}
+
+// hashOf hashes v with sqlnull.Hash, treating an invalid value as nil.
+func hashOf(v reflect.Value) uint64 {
+	if !v.IsValid() {
+		return sqlnull.Hash(nil)
+	}
+	return sqlnull.Hash(v.Interface())
+}
+
+// join appends a field name to path.
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// isList reports whether v is a slice or array other than a byte slice.
+func isList(v reflect.Value) bool {
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8
+}
+
+// render formats v for a failure message, with NULL for NULL and without pointer addresses.
+func render(v reflect.Value) string {
+	v, null := unwrap(v)
+	if null {
+		return "NULL"
+	}
+
+	switch {
+	case v.Type() == timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339Nano)
+	case v.Kind() == reflect.String:
+		return fmt.Sprintf("%q", v.String())
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return fmt.Sprintf("%q", v.Bytes())
+	case isList(v):
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = render(v.Index(i))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case v.Kind() == reflect.Struct:
+		var fields []string
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				fields = append(fields, v.Type().Field(i).Name+": "+render(v.Field(i)))
+			}
+		}
+		return v.Type().Name() + "{" + strings.Join(fields, ", ") + "}"
+	}
+	return fmt.Sprint(v.Interface())
+}
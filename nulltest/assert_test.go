@@ -0,0 +1,58 @@
+//go:build !sqlnull_lite
+
+package nulltest_test
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/ceebydith/sqlnull/nulltest"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	type User struct {
+		ID    int64
+		Phone *string
+		Tags  []string
+		Email sql.NullString
+	}
+
+	r := &recorder{}
+	require.True(t, nulltest.AssertEqual(r, "555", sqlnull.Ptr("555")))
+	require.True(t, nulltest.AssertEqual(r, sql.NullString{String: "555", Valid: true}, sqlnull.Some("555")))
+	require.True(t, nulltest.AssertEqual(r, nil, sql.NullInt64{}))
+	require.True(t, nulltest.AssertNull(r, (*string)(nil)))
+	require.True(t, nulltest.AssertNull(r, sqlnull.Optional[int]{}))
+	require.True(t, nulltest.AssertValid(r, sqlnull.Ptr(0)))
+	require.Empty(t, r.errors)
+
+	want := User{ID: 1, Phone: sqlnull.Ptr("555"), Tags: []string{"a", "b"}}
+	got := &User{ID: 1, Tags: []string{"a", "c"}, Email: sql.NullString{String: "x@y", Valid: true}}
+	require.False(t, nulltest.AssertEqual(r, want, got))
+	require.Equal(t, []string{"not equal:\n" +
+		"  Phone: want \"555\", got NULL\n" +
+		"  Tags[1]: want \"b\", got \"c\"\n" +
+		"  Email: want NULL, got \"x@y\"",
+	}, r.errors)
+
+	r.errors = nil
+	require.False(t, nulltest.AssertNull(r, sqlnull.Ptr(want)))
+	require.False(t, nulltest.AssertValid(r, sql.NullTime{}))
+	require.Equal(t, []string{
+		`expected NULL, got User{ID: 1, Phone: "555", Tags: ["a", "b"], Email: NULL}`,
+		"expected a value, got NULL for sql.NullTime",
+	}, r.errors)
+}
@@ -0,0 +1,255 @@
+//go:build !sqlnull_lite
+
+package nulltest
+
+import (
+	"context"
+	"database/sql/driver"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ChaosOptions controls how a chaos driver alters the values returned by queries.
+// Each non-NULL value is considered in turn for the replacements below, in this order.
+type ChaosOptions struct {
+	NullProbability     float64 // chance of a value being replaced by NULL
+	BytesProbability    float64 // chance of a value being replaced by its []byte text form, as MySQL returns it
+	BoundaryProbability float64 // chance of a value being replaced by a boundary value of its type, see Generate
+	Seed                int64   // seed of the random source, so failures can be reproduced
+}
+
+// Chaos wraps d so every query returns randomly altered values: NULL where the database
+// has a value, []byte where it returns a number, string or time, and boundary values. It
+// fuzzes the scan paths of an application to check it copes with unexpected nullability
+// and value types. Register the result under a name of its own:
+//
+//	sql.Register("sqlite3-chaos", nulltest.Chaos(&sqlite3.SQLiteDriver{}, nulltest.ChaosOptions{
+//		NullProbability: 0.2,
+//		Seed:            42,
+//	}))
+//
+// Statement arguments and Exec are passed through untouched.
+func Chaos(d driver.Driver, opts ChaosOptions) driver.Driver {
+	return chaosDriver{Driver: d, chaos: newChaos(opts)}
+}
+
+// ChaosConnector wraps c like Chaos wraps a driver, for use with sql.OpenDB.
+func ChaosConnector(c driver.Connector, opts ChaosOptions) driver.Connector {
+	return chaosConnector{Connector: c, chaos: newChaos(opts)}
+}
+
+// chaos alters driver values from a seeded random source shared by every connection.
+type chaos struct {
+	mu   sync.Mutex
+	gen  *generator
+	opts ChaosOptions
+}
+
+func newChaos(opts ChaosOptions) *chaos {
+	return &chaos{
+		gen: &generator{
+			rand: rand.New(rand.NewSource(opts.Seed)),
+			opts: Options{BoundaryProbability: 1},
+		},
+		opts: opts,
+	}
+}
+
+// value returns v, possibly replaced by NULL, its text form or a boundary value.
+func (c *chaos) value(v driver.Value) driver.Value {
+	if v == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case c.gen.chance(c.opts.NullProbability):
+		return nil
+	case c.gen.chance(c.opts.BytesProbability):
+		return textForm(v)
+	case c.gen.chance(c.opts.BoundaryProbability):
+		val := reflect.New(reflect.TypeOf(v)).Elem()
+		c.gen.fill(val)
+		return val.Interface()
+	}
+	return v
+}
+
+// textForm returns v as the []byte a text protocol driver would return.
+func textForm(v driver.Value) driver.Value {
+	switch v := v.(type) {
+	case int64:
+		return []byte(strconv.FormatInt(v, 10))
+	case float64:
+		return []byte(strconv.FormatFloat(v, 'g', -1, 64))
+	case bool:
+		if v {
+			return []byte("1")
+		}
+		return []byte("0")
+	case string:
+		return []byte(v)
+	case time.Time:
+		return []byte(v.Format("2006-01-02 15:04:05.999999999"))
+	}
+	return v
+}
+
+// chaosDriver, chaosConnector, chaosConn and chaosStmt pass everything through to the
+// wrapped driver, wrapping the rows returned by queries with chaosRows.
+type chaosDriver struct {
+	driver.Driver
+	chaos *chaos
+}
+
+func (d chaosDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return chaosConn{Conn: conn, chaos: d.chaos}, nil
+}
+
+type chaosConnector struct {
+	driver.Connector
+	chaos *chaos
+}
+
+func (c chaosConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return chaosConn{Conn: conn, chaos: c.chaos}, nil
+}
+
+func (c chaosConnector) Driver() driver.Driver {
+	return chaosDriver{Driver: c.Connector.Driver(), chaos: c.chaos}
+}
+
+type chaosConn struct {
+	driver.Conn
+	chaos *chaos
+}
+
+func (c chaosConn) Prepare(query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	return chaosStmt{Stmt: stmt, chaos: c.chaos}, nil
+}
+
+// PrepareContext implements driver.ConnPrepareContext.
+func (c chaosConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	prep, ok := c.Conn.(driver.ConnPrepareContext)
+	if !ok {
+		return c.Prepare(query)
+	}
+	stmt, err := prep.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return chaosStmt{Stmt: stmt, chaos: c.chaos}, nil
+}
+
+// QueryContext implements driver.QueryerContext.
+func (c chaosConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return chaosRows{Rows: rows, chaos: c.chaos}, nil
+}
+
+// ExecContext implements driver.ExecerContext.
+func (c chaosConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return execer.ExecContext(ctx, query, args)
+}
+
+// BeginTx implements driver.ConnBeginTx.
+func (c chaosConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if begin, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return begin.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+// CheckNamedValue implements driver.NamedValueChecker.
+func (c chaosConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+type chaosStmt struct {
+	driver.Stmt
+	chaos *chaos
+}
+
+func (s chaosStmt) Query(args []driver.Value) (driver.Rows, error) {
+	rows, err := s.Stmt.Query(args)
+	if err != nil {
+		return nil, err
+	}
+	return chaosRows{Rows: rows, chaos: s.chaos}, nil
+}
+
+// QueryContext implements driver.StmtQueryContext.
+func (s chaosStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		return s.Query(values(args))
+	}
+	rows, err := queryer.QueryContext(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return chaosRows{Rows: rows, chaos: s.chaos}, nil
+}
+
+// ExecContext implements driver.StmtExecContext.
+func (s chaosStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+	return s.Stmt.Exec(values(args))
+}
+
+// values drops the names of args.
+func values(args []driver.NamedValue) []driver.Value {
+	result := make([]driver.Value, len(args))
+	for i, arg := range args {
+		result[i] = arg.Value
+	}
+	return result
+}
+
+// chaosRows alters the values of every row read from the wrapped rows.
+type chaosRows struct {
+	driver.Rows
+	chaos *chaos
+}
+
+func (r chaosRows) Next(dest []driver.Value) error {
+	if err := r.Rows.Next(dest); err != nil {
+		return err
+	}
+	for i := range dest {
+		dest[i] = r.chaos.value(dest[i])
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+//go:build !sqlnull_lite
+
+package nulltest_test
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+
+	"github.com/ceebydith/sqlnull/nulltest"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func openChaos(t *testing.T, name string, opts nulltest.ChaosOptions) *sql.DB {
+	sql.Register("sqlite3-chaos-"+name, nulltest.Chaos(&sqlite3.SQLiteDriver{}, opts))
+	db, err := sql.Open("sqlite3-chaos-"+name, ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func queryValues(t *testing.T, db *sql.DB, query string, args ...any) []any {
+	values := make([]any, 3)
+	require.NoError(t, db.QueryRow(query, args...).Scan(&values[0], &values[1], &values[2]))
+	return values
+}
+
+func TestChaos(t *testing.T) {
+	const query = "SELECT 42, 'john', ?"
+
+	db := openChaos(t, "null", nulltest.ChaosOptions{NullProbability: 1})
+	require.Equal(t, []any{nil, nil, nil}, queryValues(t, db, query, 1.5))
+
+	db = openChaos(t, "bytes", nulltest.ChaosOptions{BytesProbability: 1})
+	require.Equal(t, []any{[]byte("42"), []byte("john"), []byte("1.5")}, queryValues(t, db, query, 1.5))
+
+	db = openChaos(t, "boundary", nulltest.ChaosOptions{BoundaryProbability: 1, Seed: 7})
+	values := queryValues(t, db, query, 1.5)
+	require.Contains(t, []any{int64(0), int64(-1), int64(1), int64(math.MinInt64), int64(math.MaxInt64)}, values[0])
+	require.IsType(t, "", values[1])
+	require.Contains(t, []any{0.0, -1.0, 1.0, math.MaxFloat64, -math.MaxFloat64}, values[2])
+
+	// the same seed alters the same values
+	a := openChaos(t, "seed-a", nulltest.ChaosOptions{NullProbability: 0.5, Seed: 3})
+	b := openChaos(t, "seed-b", nulltest.ChaosOptions{NullProbability: 0.5, Seed: 3})
+	for i := 0; i < 10; i++ {
+		require.Equal(t, queryValues(t, a, query, i), queryValues(t, b, query, i))
+	}
+
+	var n int
+	require.Error(t, a.QueryRow("SELECT NULL").Scan(&n))
+}
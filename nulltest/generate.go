@@ -0,0 +1,176 @@
+//go:build !sqlnull_lite
+
+// Package nulltest provides helpers for testing code built on sqlnull.
+package nulltest
+
+import (
+	"database/sql"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+)
+
+// Options controls how Generate fills struct instances.
+type Options struct {
+	Count               int     // number of instances to generate, 1 when zero
+	NullProbability     float64 // chance of a nullable field (pointer or sql.Null wrapper) being NULL
+	BoundaryProbability float64 // chance of a value being a boundary value instead of a random one
+	Seed                int64   // seed of the random source, so failures can be reproduced
+}
+
+// Generate creates opts.Count instances of T with randomly NULL fields and boundary values,
+// for property-style testing of null handling. The same Seed always yields the same instances.
+//
+// Boundary values include zero, minimum and maximum numbers, empty and non-ASCII strings,
+// the Unix epoch and the year 9999. Unsigned maximums are capped at math.MaxInt64, the
+// largest value database/sql accepts as a statement argument.
+func Generate[T any](opts Options) []T {
+	g := &generator{
+		rand: rand.New(rand.NewSource(opts.Seed)),
+		opts: opts,
+	}
+
+	count := opts.Count
+	if count <= 0 {
+		count = 1
+	}
+
+	result := make([]T, count)
+	for i := range result {
+		g.fill(reflect.ValueOf(&result[i]).Elem())
+	}
+	return result
+}
+
+// Insert inserts the items into table using the statements built by sqlnull.Insert.
+func Insert[T any](db *sql.DB, table string, items []T) error {
+	for i := range items {
+		query, args, err := sqlnull.Insert(table, &items[i])
+		if err != nil {
+			return err
+		}
+		if _, err := db.Exec(query, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generator fills values from a seeded random source.
+type generator struct {
+	rand *rand.Rand
+	opts Options
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+func (g *generator) chance(p float64) bool {
+	return p > 0 && g.rand.Float64() < p
+}
+
+// fill sets val, which must be settable, to a generated value of its type.
+func (g *generator) fill(val reflect.Value) {
+	switch val.Kind() {
+	case reflect.Ptr:
+		if g.chance(g.opts.NullProbability) {
+			val.Set(reflect.Zero(val.Type()))
+			return
+		}
+		val.Set(reflect.New(val.Type().Elem()))
+		g.fill(val.Elem())
+	case reflect.Bool:
+		val.SetBool(g.rand.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := val.Type().Bits()
+		if g.chance(g.opts.BoundaryProbability) {
+			max := int64(1)<<(bits-1) - 1
+			val.SetInt([]int64{0, -1, 1, -max - 1, max}[g.rand.Intn(5)])
+			return
+		}
+		val.SetInt(g.rand.Int63n(int64(1)<<(bits-2)) - int64(1)<<(bits-3))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bits := val.Type().Bits()
+		max := uint64(math.MaxInt64)
+		if bits < 64 {
+			max = uint64(1)<<bits - 1
+		}
+		if g.chance(g.opts.BoundaryProbability) {
+			val.SetUint([]uint64{0, 1, max}[g.rand.Intn(3)])
+			return
+		}
+		val.SetUint(uint64(g.rand.Int63n(int64(max/2) + 1)))
+	case reflect.Float32, reflect.Float64:
+		if g.chance(g.opts.BoundaryProbability) {
+			max := math.MaxFloat64
+			if val.Kind() == reflect.Float32 {
+				max = math.MaxFloat32
+			}
+			val.SetFloat([]float64{0, -1, 1, max, -max}[g.rand.Intn(5)])
+			return
+		}
+		val.SetFloat(math.Round((g.rand.Float64()*2000-1000)*100) / 100)
+	case reflect.String:
+		if g.chance(g.opts.BoundaryProbability) {
+			val.SetString([]string{"", " ", "O'Reilly", "héllo wörld ✓", strings.Repeat("x", 1024)}[g.rand.Intn(5)])
+			return
+		}
+		val.SetString(g.word())
+	case reflect.Slice:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			if g.chance(g.opts.BoundaryProbability) {
+				val.SetBytes([]byte{})
+				return
+			}
+			b := make([]byte, 1+g.rand.Intn(32))
+			g.rand.Read(b)
+			val.SetBytes(b)
+		}
+	case reflect.Struct:
+		if val.Type() == timeType {
+			val.Set(reflect.ValueOf(g.time()))
+			return
+		}
+		if reflect.PointerTo(val.Type()).Implements(scannerType) && val.NumField() == 2 && val.Type().Field(1).Name == "Valid" {
+			// sql.Null wrappers: the value followed by Valid
+			if !g.chance(g.opts.NullProbability) {
+				g.fill(val.Field(0))
+				val.Field(1).SetBool(true)
+			}
+			return
+		}
+		for i := 0; i < val.NumField(); i++ {
+			if val.Type().Field(i).IsExported() {
+				g.fill(val.Field(i))
+			}
+		}
+	}
+}
+
+// word returns a random lowercase word.
+func (g *generator) word() string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	b := make([]byte, 3+g.rand.Intn(10))
+	for i := range b {
+		b[i] = letters[g.rand.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+// time returns a random UTC time with second precision, or a boundary time.
+func (g *generator) time() time.Time {
+	if g.chance(g.opts.BoundaryProbability) {
+		return []time.Time{
+			time.Unix(0, 0).UTC(),
+			time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+			time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC),
+		}[g.rand.Intn(3)]
+	}
+	return time.Unix(g.rand.Int63n(4102444800), 0).UTC()
+}
@@ -0,0 +1,77 @@
+//go:build !sqlnull_lite
+
+package nulltest_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/ceebydith/sqlnull/nulltest"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+type Account struct {
+	ID        int64
+	Name      *string
+	Balance   *float64
+	Active    sql.NullBool
+	Level     uint8
+	CreatedAt *time.Time
+}
+
+func TestGenerate(t *testing.T) {
+	accounts := nulltest.Generate[Account](nulltest.Options{Count: 200, NullProbability: 0.5, Seed: 1})
+	require.Len(t, accounts, 200)
+	require.Equal(t, accounts, nulltest.Generate[Account](nulltest.Options{Count: 200, NullProbability: 0.5, Seed: 1}))
+
+	var nulls, valued int
+	for _, account := range accounts {
+		if account.Name == nil {
+			nulls++
+		} else {
+			valued++
+		}
+	}
+	require.NotZero(t, nulls)
+	require.NotZero(t, valued)
+
+	none := nulltest.Generate[Account](nulltest.Options{Count: 20, Seed: 2})
+	for _, account := range none {
+		require.NotNil(t, account.Name)
+		require.NotNil(t, account.CreatedAt)
+		require.True(t, account.Active.Valid)
+	}
+
+	require.Len(t, nulltest.Generate[Account](nulltest.Options{}), 1)
+}
+
+func TestGenerateInsert(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE [accounts] ([id] INTEGER, [name] TEXT, [balance] FLOAT, [active] BOOLEAN, [level] INTEGER, [created_at] DATETIME)`)
+	require.NoError(t, err)
+
+	accounts := nulltest.Generate[Account](nulltest.Options{Count: 50, NullProbability: 0.3, BoundaryProbability: 0.3, Seed: 3})
+	require.NoError(t, nulltest.Insert(db, "accounts", accounts))
+
+	rows, err := db.Query("SELECT id, name, active FROM accounts")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var i int
+	for rows.Next() {
+		var account Account
+		require.NoError(t, sqlnull.ScanStruct(rows, &account))
+		require.Equal(t, accounts[i].Name, account.Name)
+		require.Equal(t, accounts[i].Active, account.Active)
+		i++
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, 50, i)
+}
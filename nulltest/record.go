@@ -0,0 +1,282 @@
+//go:build !sqlnull_lite
+
+package nulltest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Recording holds a captured result set: its columns and every row with its NULL pattern.
+// Recordings are saved as JSON, so they can be checked in next to the tests using them.
+type Recording struct {
+	Columns []RecordedColumn  `json:"columns"`
+	Rows    [][]RecordedValue `json:"rows"`
+}
+
+// RecordedColumn describes a column of a Recording.
+type RecordedColumn struct {
+	Name             string `json:"name"`
+	DatabaseTypeName string `json:"database_type_name,omitempty"`
+	ScanType         string `json:"scan_type,omitempty"`
+	Nullable         *bool  `json:"nullable,omitempty"`
+}
+
+// RecordedValue is a single driver value. Value is nil for NULL, otherwise it holds
+// the text form of a value whose driver type is given by Type.
+type RecordedValue struct {
+	Type  string  `json:"type,omitempty"`
+	Value *string `json:"value"`
+}
+
+// Record reads all remaining rows into a Recording and closes rows.
+func Record(rows *sql.Rows) (*Recording, error) {
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &Recording{}
+	for _, ct := range columnTypes {
+		column := RecordedColumn{
+			Name:             ct.Name(),
+			DatabaseTypeName: ct.DatabaseTypeName(),
+		}
+		if scanType := ct.ScanType(); scanType != nil {
+			column.ScanType = scanType.String()
+		}
+		if nullable, ok := ct.Nullable(); ok {
+			column.Nullable = &nullable
+		}
+		rec.Columns = append(rec.Columns, column)
+	}
+
+	values := make([]any, len(columnTypes))
+	targets := make([]any, len(columnTypes))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(targets...); err != nil {
+			return nil, err
+		}
+		row := make([]RecordedValue, len(values))
+		for i, value := range values {
+			if row[i], err = encodeValue(value); err != nil {
+				return nil, fmt.Errorf("column %q: %w", rec.Columns[i].Name, err)
+			}
+		}
+		rec.Rows = append(rec.Rows, row)
+	}
+	return rec, rows.Err()
+}
+
+// Save writes the recording to the file at path.
+func (r *Recording) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := r.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Write writes the recording to w as JSON.
+func (r *Recording) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Load reads a recording from the file at path.
+func Load(path string) (*Recording, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read reads a recording written by Write.
+func Read(r io.Reader) (*Recording, error) {
+	var rec Recording
+	if err := json.NewDecoder(r).Decode(&rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// Replay returns a driver.Rows replaying the recording.
+func (r *Recording) Replay() driver.Rows {
+	return &replayRows{rec: r}
+}
+
+// Open returns a database whose every query returns the recorded rows,
+// so scanning code can be tested without the original database.
+func (r *Recording) Open() *sql.DB {
+	return sql.OpenDB(replayConnector{rec: r})
+}
+
+// encodeValue converts a driver value to its recorded form.
+func encodeValue(value any) (RecordedValue, error) {
+	var typ, text string
+	switch v := value.(type) {
+	case nil:
+		return RecordedValue{}, nil
+	case int64:
+		typ, text = "int64", strconv.FormatInt(v, 10)
+	case float64:
+		typ, text = "float64", strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		typ, text = "bool", strconv.FormatBool(v)
+	case string:
+		typ, text = "string", v
+	case []byte:
+		typ, text = "bytes", base64.StdEncoding.EncodeToString(v)
+	case time.Time:
+		typ, text = "time", v.Format(time.RFC3339Nano)
+	default:
+		return RecordedValue{}, fmt.Errorf("unsupported driver value type %T", value)
+	}
+	return RecordedValue{Type: typ, Value: &text}, nil
+}
+
+// decode converts the recorded form back to a driver value.
+func (v RecordedValue) decode() (driver.Value, error) {
+	if v.Value == nil {
+		return nil, nil
+	}
+	text := *v.Value
+	switch v.Type {
+	case "int64":
+		return strconv.ParseInt(text, 10, 64)
+	case "float64":
+		return strconv.ParseFloat(text, 64)
+	case "bool":
+		return strconv.ParseBool(text)
+	case "string":
+		return text, nil
+	case "bytes":
+		return base64.StdEncoding.DecodeString(text)
+	case "time":
+		return time.Parse(time.RFC3339Nano, text)
+	}
+	return nil, fmt.Errorf("unsupported recorded value type %q", v.Type)
+}
+
+// replayRows implements driver.Rows over a Recording.
+type replayRows struct {
+	rec *Recording
+	pos int
+}
+
+func (r *replayRows) Columns() []string {
+	columns := make([]string, len(r.rec.Columns))
+	for i, column := range r.rec.Columns {
+		columns[i] = column.Name
+	}
+	return columns
+}
+
+func (r *replayRows) Close() error {
+	return nil
+}
+
+func (r *replayRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rec.Rows) {
+		return io.EOF
+	}
+	row := r.rec.Rows[r.pos]
+	r.pos++
+	for i := range dest {
+		value, err := row[i].decode()
+		if err != nil {
+			return fmt.Errorf("column %q: %w", r.rec.Columns[i].Name, err)
+		}
+		dest[i] = value
+	}
+	return nil
+}
+
+// ColumnTypeDatabaseTypeName implements driver.RowsColumnTypeDatabaseTypeName.
+func (r *replayRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.rec.Columns[index].DatabaseTypeName
+}
+
+// ColumnTypeNullable implements driver.RowsColumnTypeNullable.
+func (r *replayRows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	if n := r.rec.Columns[index].Nullable; n != nil {
+		return *n, true
+	}
+	return false, false
+}
+
+// replayConnector, replayConn and replayStmt serve a Recording through database/sql.
+type replayConnector struct {
+	rec *Recording
+}
+
+func (c replayConnector) Connect(context.Context) (driver.Conn, error) {
+	return replayConn(c), nil
+}
+
+func (c replayConnector) Driver() driver.Driver {
+	return replayDriver{}
+}
+
+type replayDriver struct{}
+
+func (replayDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("nulltest: replay driver must be opened with Recording.Open")
+}
+
+type replayConn struct {
+	rec *Recording
+}
+
+func (c replayConn) Prepare(string) (driver.Stmt, error) {
+	return replayStmt(c), nil
+}
+
+func (c replayConn) Close() error {
+	return nil
+}
+
+func (c replayConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("nulltest: replay driver does not support transactions")
+}
+
+type replayStmt struct {
+	rec *Recording
+}
+
+func (s replayStmt) Close() error {
+	return nil
+}
+
+func (s replayStmt) NumInput() int {
+	return -1
+}
+
+func (s replayStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("nulltest: replay driver does not support Exec")
+}
+
+func (s replayStmt) Query([]driver.Value) (driver.Rows, error) {
+	return s.rec.Replay(), nil
+}
@@ -0,0 +1,72 @@
+//go:build !sqlnull_lite
+
+package nulltest_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/ceebydith/sqlnull/nulltest"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReplay(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	created := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	_, err = db.Exec(`
+		CREATE TABLE [accounts] ([id] INTEGER, [name] TEXT, [balance] FLOAT, [active] BOOLEAN, [level] INTEGER, [created_at] DATETIME, [avatar] BLOB);
+		INSERT INTO accounts VALUES (1, 'john', 10.5, 1, 3, ?, x'0102'), (2, NULL, NULL, NULL, NULL, NULL, NULL);
+	`, created)
+	require.NoError(t, err)
+
+	rows, err := db.Query("SELECT id, name, balance, active, level, created_at, avatar FROM accounts ORDER BY id")
+	require.NoError(t, err)
+	rec, err := nulltest.Record(rows)
+	require.NoError(t, err)
+	require.Len(t, rec.Rows, 2)
+	require.Equal(t, "TEXT", rec.Columns[1].DatabaseTypeName)
+
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	require.NoError(t, rec.Save(path))
+	loaded, err := nulltest.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, rec, loaded)
+
+	replay := loaded.Open()
+	defer replay.Close()
+
+	rows, err = replay.Query("SELECT anything")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	type Row struct {
+		Account
+		Avatar *[]byte
+	}
+	var got []Row
+	for rows.Next() {
+		var row Row
+		require.NoError(t, sqlnull.ScanStruct(rows, &row))
+		got = append(got, row)
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, got, 2)
+	require.Equal(t, "john", *got[0].Name)
+	require.Equal(t, 10.5, *got[0].Balance)
+	require.True(t, got[0].Active.Bool)
+	require.Equal(t, uint8(3), got[0].Level)
+	require.True(t, created.Equal(*got[0].CreatedAt))
+	require.Equal(t, []byte{1, 2}, *got[0].Avatar)
+	require.Nil(t, got[1].Name)
+	require.Nil(t, got[1].CreatedAt)
+	require.False(t, got[1].Active.Valid)
+	require.Nil(t, got[1].Avatar)
+}
@@ -0,0 +1,152 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"time"
+)
+
+// FromPtr converts a pointer into a sql.Null, with nil becoming an invalid value.
+func FromPtr[T any](p *T) sql.Null[T] {
+	if p == nil {
+		return sql.Null[T]{}
+	}
+	return sql.Null[T]{V: *p, Valid: true}
+}
+
+// ToPtr converts a sql.Null into a pointer, with an invalid value becoming nil.
+func ToPtr[T any](n sql.Null[T]) *T {
+	if !n.Valid {
+		return nil
+	}
+	return &n.V
+}
+
+// NullBoolFrom converts a pointer into a sql.NullBool, with nil becoming an invalid value.
+func NullBoolFrom(p *bool) sql.NullBool {
+	if p == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *p, Valid: true}
+}
+
+// NullBoolPtr converts a sql.NullBool into a pointer, with an invalid value becoming nil.
+func NullBoolPtr(n sql.NullBool) *bool {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Bool
+}
+
+// NullByteFrom converts a pointer into a sql.NullByte, with nil becoming an invalid value.
+func NullByteFrom(p *byte) sql.NullByte {
+	if p == nil {
+		return sql.NullByte{}
+	}
+	return sql.NullByte{Byte: *p, Valid: true}
+}
+
+// NullBytePtr converts a sql.NullByte into a pointer, with an invalid value becoming nil.
+func NullBytePtr(n sql.NullByte) *byte {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Byte
+}
+
+// NullInt16From converts a pointer into a sql.NullInt16, with nil becoming an invalid value.
+func NullInt16From(p *int16) sql.NullInt16 {
+	if p == nil {
+		return sql.NullInt16{}
+	}
+	return sql.NullInt16{Int16: *p, Valid: true}
+}
+
+// NullInt16Ptr converts a sql.NullInt16 into a pointer, with an invalid value becoming nil.
+func NullInt16Ptr(n sql.NullInt16) *int16 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int16
+}
+
+// NullInt32From converts a pointer into a sql.NullInt32, with nil becoming an invalid value.
+func NullInt32From(p *int32) sql.NullInt32 {
+	if p == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *p, Valid: true}
+}
+
+// NullInt32Ptr converts a sql.NullInt32 into a pointer, with an invalid value becoming nil.
+func NullInt32Ptr(n sql.NullInt32) *int32 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int32
+}
+
+// NullInt64From converts a pointer into a sql.NullInt64, with nil becoming an invalid value.
+func NullInt64From(p *int64) sql.NullInt64 {
+	if p == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *p, Valid: true}
+}
+
+// NullInt64Ptr converts a sql.NullInt64 into a pointer, with an invalid value becoming nil.
+func NullInt64Ptr(n sql.NullInt64) *int64 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int64
+}
+
+// NullFloat64From converts a pointer into a sql.NullFloat64, with nil becoming an invalid value.
+func NullFloat64From(p *float64) sql.NullFloat64 {
+	if p == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *p, Valid: true}
+}
+
+// NullFloat64Ptr converts a sql.NullFloat64 into a pointer, with an invalid value becoming nil.
+func NullFloat64Ptr(n sql.NullFloat64) *float64 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Float64
+}
+
+// NullStringFrom converts a pointer into a sql.NullString, with nil becoming an invalid value.
+func NullStringFrom(p *string) sql.NullString {
+	if p == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *p, Valid: true}
+}
+
+// NullStringPtr converts a sql.NullString into a pointer, with an invalid value becoming nil.
+func NullStringPtr(n sql.NullString) *string {
+	if !n.Valid {
+		return nil
+	}
+	return &n.String
+}
+
+// NullTimeFrom converts a pointer into a sql.NullTime, with nil becoming an invalid value.
+func NullTimeFrom(p *time.Time) sql.NullTime {
+	if p == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *p, Valid: true}
+}
+
+// NullTimePtr converts a sql.NullTime into a pointer, with an invalid value becoming nil.
+func NullTimePtr(n sql.NullTime) *time.Time {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Time
+}
@@ -0,0 +1,41 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromToPtr(t *testing.T) {
+	require.Equal(t, sql.Null[int64]{V: 7, Valid: true}, sqlnull.FromPtr(sqlnull.Ptr[int64](7)))
+	require.Equal(t, sql.Null[int64]{}, sqlnull.FromPtr[int64](nil))
+
+	require.Equal(t, "lorem", *sqlnull.ToPtr(sql.Null[string]{V: "lorem", Valid: true}))
+	require.Nil(t, sqlnull.ToPtr(sql.Null[string]{V: "lorem"}))
+}
+
+func TestNullConstructors(t *testing.T) {
+	now := time.Now()
+
+	require.Equal(t, sql.NullBool{Bool: true, Valid: true}, sqlnull.NullBoolFrom(sqlnull.Ptr(true)))
+	require.Equal(t, sql.NullByte{Byte: 9, Valid: true}, sqlnull.NullByteFrom(sqlnull.Ptr[byte](9)))
+	require.Equal(t, sql.NullInt16{Int16: 9, Valid: true}, sqlnull.NullInt16From(sqlnull.Ptr[int16](9)))
+	require.Equal(t, sql.NullInt32{Int32: 9, Valid: true}, sqlnull.NullInt32From(sqlnull.Ptr[int32](9)))
+	require.Equal(t, sql.NullInt64{Int64: 9, Valid: true}, sqlnull.NullInt64From(sqlnull.Ptr[int64](9)))
+	require.Equal(t, sql.NullFloat64{Float64: 1.5, Valid: true}, sqlnull.NullFloat64From(sqlnull.Ptr(1.5)))
+	require.Equal(t, sql.NullString{String: "lorem", Valid: true}, sqlnull.NullStringFrom(sqlnull.Ptr("lorem")))
+	require.Equal(t, sql.NullTime{Time: now, Valid: true}, sqlnull.NullTimeFrom(&now))
+	require.Equal(t, sql.NullString{}, sqlnull.NullStringFrom(nil))
+	require.Equal(t, sql.NullTime{}, sqlnull.NullTimeFrom(nil))
+
+	require.Equal(t, true, *sqlnull.NullBoolPtr(sql.NullBool{Bool: true, Valid: true}))
+	require.Equal(t, int64(9), *sqlnull.NullInt64Ptr(sql.NullInt64{Int64: 9, Valid: true}))
+	require.Equal(t, now, *sqlnull.NullTimePtr(sql.NullTime{Time: now, Valid: true}))
+	require.Nil(t, sqlnull.NullStringPtr(sql.NullString{String: "lorem"}))
+	require.Nil(t, sqlnull.NullFloat64Ptr(sql.NullFloat64{}))
+}
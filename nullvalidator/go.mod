@@ -0,0 +1,24 @@
+module github.com/ceebydith/sqlnull/nullvalidator
+
+go 1.23.3
+
+require (
+	github.com/ceebydith/sqlnull v0.0.0-20261015040039-9285dc7f2c41
+	github.com/go-playground/validator/v10 v10.22.1
+	github.com/mattn/go-sqlite3 v1.14.24
+	github.com/stretchr/testify v1.9.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/gabriel-vasile/mimetype v1.4.3 // indirect
+	github.com/go-playground/locales v0.14.1 // indirect
+	github.com/go-playground/universal-translator v0.18.1 // indirect
+	github.com/leodido/go-urn v1.4.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/crypto v0.19.0 // indirect
+	golang.org/x/net v0.21.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
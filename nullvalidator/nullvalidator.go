@@ -0,0 +1,145 @@
+// Package nullvalidator integrates sqlnull models with github.com/go-playground/validator.
+//
+// It registers nil-aware validation rules, so pointer fields and sql.Null wrappers
+// can be validated without dereference panics, and offers a ScanStruct helper that
+// validates the struct right after it is scanned.
+//
+// Example:
+//
+//	type User struct {
+//		ID    int64
+//		Email *string `validate:"nullable_email"`
+//		Name  *string `validate:"required_if_valid"`
+//	}
+//
+//	validate, err := nullvalidator.New()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	for rows.Next() {
+//		var user User
+//		if err := nullvalidator.ScanStruct(validate, rows, &user); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+package nullvalidator
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/go-playground/validator/v10"
+)
+
+// New creates a validator with the rules of this package registered.
+func New() (*validator.Validate, error) {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	if err := Register(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Register adds the nil-aware rules to v:
+//
+//   - required_if_valid: NULL passes, a present value must not be the zero value.
+//   - nullable_email: NULL passes, a present value must be a valid email address.
+//
+// It also teaches v to validate the value held by the sql.Null wrappers,
+// which are seen as a nil pointer when they are not valid.
+func Register(v *validator.Validate) error {
+	if err := v.RegisterValidation("required_if_valid", requiredIfValid, true); err != nil {
+		return err
+	}
+	if err := RegisterNullable(v, "nullable_email", "email"); err != nil {
+		return err
+	}
+
+	v.RegisterCustomTypeFunc(nullValue,
+		sql.NullBool{},
+		sql.NullByte{},
+		sql.NullInt16{},
+		sql.NullInt32{},
+		sql.NullInt64{},
+		sql.NullFloat64{},
+		sql.NullString{},
+		sql.NullTime{},
+	)
+	return nil
+}
+
+// RegisterNullable registers name as a rule that passes for NULL and otherwise applies tag,
+// e.g. RegisterNullable(v, "nullable_url", "url").
+func RegisterNullable(v *validator.Validate, name string, tag string) error {
+	return v.RegisterValidation(name, func(fl validator.FieldLevel) bool {
+		field, ok := present(fl.Field())
+		if !ok {
+			return true
+		}
+		return v.Var(field.Interface(), tag) == nil
+	}, true)
+}
+
+// ScanStruct scans the current row of rows into dest with sqlnull.ScanStruct and validates the result with v.
+func ScanStruct(v *validator.Validate, rows *sql.Rows, dest any) error {
+	if err := sqlnull.ScanStruct(rows, dest); err != nil {
+		return err
+	}
+	return v.Struct(dest)
+}
+
+// requiredIfValid passes for NULL and requires a present value to be non-zero.
+func requiredIfValid(fl validator.FieldLevel) bool {
+	field, ok := present(fl.Field())
+	return !ok || !field.IsZero()
+}
+
+// present dereferences field, reporting false when it holds NULL.
+func present(field reflect.Value) (reflect.Value, bool) {
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return field, false
+		}
+		field = field.Elem()
+	}
+	return field, field.IsValid()
+}
+
+// nullValue returns the value held by a sql.Null wrapper, or a nil pointer of
+// the same type when it is not valid.
+func nullValue(field reflect.Value) any {
+	valuer, ok := field.Interface().(driver.Valuer)
+	if !ok {
+		return nil
+	}
+	value, err := valuer.Value()
+	if err != nil {
+		return nil
+	}
+
+	switch field.Interface().(type) {
+	case sql.NullBool:
+		return ptr[bool](value)
+	case sql.NullByte, sql.NullInt16, sql.NullInt32, sql.NullInt64:
+		return ptr[int64](value)
+	case sql.NullFloat64:
+		return ptr[float64](value)
+	case sql.NullString:
+		return ptr[string](value)
+	case sql.NullTime:
+		return ptr[time.Time](value)
+	}
+	return nil
+}
+
+// ptr returns a pointer to value, or a nil *T when value is nil.
+func ptr[T any](value any) *T {
+	if v, ok := value.(T); ok {
+		return &v
+	}
+	return nil
+}
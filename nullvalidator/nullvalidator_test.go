@@ -0,0 +1,62 @@
+package nullvalidator_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ceebydith/sqlnull/nullvalidator"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+type Contact struct {
+	ID     int64
+	Email  *string        `validate:"nullable_email"`
+	Name   *string        `validate:"required_if_valid"`
+	Backup sql.NullString `validate:"nullable_email"`
+}
+
+func TestRules(t *testing.T) {
+	validate, err := nullvalidator.New()
+	require.NoError(t, err)
+
+	email := "john@example.com"
+	name := "john"
+	require.NoError(t, validate.Struct(&Contact{}))
+	require.NoError(t, validate.Struct(&Contact{Email: &email, Name: &name}))
+	require.NoError(t, validate.Struct(&Contact{Backup: sql.NullString{String: email, Valid: true}}))
+
+	invalid := "not an email"
+	empty := ""
+	require.Error(t, validate.Struct(&Contact{Email: &invalid}))
+	require.Error(t, validate.Struct(&Contact{Name: &empty}))
+	require.Error(t, validate.Struct(&Contact{Backup: sql.NullString{String: invalid, Valid: true}}))
+}
+
+func TestScanStruct(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`
+		CREATE TABLE [contacts] ([id] INTEGER, [email] TEXT, [name] TEXT, [backup] TEXT);
+		INSERT INTO contacts (id, email, name, backup) VALUES (1, 'john@example.com', NULL, NULL), (2, 'broken', 'jane', NULL);
+	`)
+	require.NoError(t, err)
+
+	validate, err := nullvalidator.New()
+	require.NoError(t, err)
+
+	rows, err := db.Query("SELECT id, email, name, backup FROM contacts ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var contact Contact
+	require.True(t, rows.Next())
+	require.NoError(t, nullvalidator.ScanStruct(validate, rows, &contact))
+	require.Nil(t, contact.Name)
+
+	require.True(t, rows.Next())
+	require.Error(t, nullvalidator.ScanStruct(validate, rows, &contact))
+}
@@ -0,0 +1,103 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+var ratType = reflect.TypeOf(big.Rat{})
+
+// WithExactNumerics protects NUMERIC/DECIMAL values from silent rounding, for financial
+// pipelines. Text values, the form most drivers use for these columns, fail to scan into
+// float targets with an error when float64 (or float32) cannot represent them exactly.
+// Use string or *big.Rat targets to keep every digit.
+func WithExactNumerics() Option {
+	return func(c *Config) {
+		c.exactNumerics = true
+	}
+}
+
+// exactFloat returns an error when src is decimal text that a float of kind cannot hold exactly.
+func exactFloat(src any, kind reflect.Kind) error {
+	if kind != reflect.Float32 && kind != reflect.Float64 {
+		return nil
+	}
+
+	var text string
+	switch v := src.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return nil
+	}
+
+	r, ok := new(big.Rat).SetString(text)
+	if !ok {
+		// not a number, leave the error to the conversion itself
+		return nil
+	}
+	exact := false
+	if kind == reflect.Float32 {
+		_, exact = r.Float32()
+	} else {
+		_, exact = r.Float64()
+	}
+	if !exact {
+		return fmt.Errorf("value %s cannot be represented exactly as %s", text, kind)
+	}
+	return nil
+}
+
+// nullRat scans NUMERIC/DECIMAL columns into big.Rat targets without loss of precision.
+type nullRat struct {
+	value *big.Rat
+}
+
+// Scan implements the sql.Scanner interface for nullRat.
+func (n *nullRat) Scan(src any) error {
+	n.value = nil
+
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case int64:
+		n.value = new(big.Rat).SetInt64(v)
+	case float64:
+		r := new(big.Rat).SetFloat64(v)
+		if r == nil {
+			return fmt.Errorf("cannot scan %v into big.Rat", v)
+		}
+		n.value = r
+	case string:
+		return n.parse(v)
+	case []byte:
+		return n.parse(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T value into big.Rat", src)
+	}
+	return nil
+}
+
+// parse reads a decimal or fractional text value.
+func (n *nullRat) parse(s string) error {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return fmt.Errorf("converting %q to big.Rat: invalid syntax", s)
+	}
+	n.value = r
+	return nil
+}
+
+// Value implements the driver.Valuer interface for nullRat.
+func (n *nullRat) Value() (driver.Value, error) {
+	if n.value == nil {
+		return nil, nil
+	}
+	return *n.value, nil
+}
@@ -0,0 +1,71 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigRat(t *testing.T) {
+	var amount *big.Rat
+	require.NoError(t, sqlnull.New(&amount).Scan("12345678901234567890.123456789"))
+	require.Equal(t, "12345678901234567890.123456789", amount.FloatString(9))
+
+	require.NoError(t, sqlnull.New(&amount).Scan(int64(7)))
+	require.Equal(t, "7", amount.RatString())
+
+	require.NoError(t, sqlnull.New(&amount).Scan(nil))
+	require.Nil(t, amount)
+
+	require.Error(t, sqlnull.New(&amount).Scan("lorem"))
+}
+
+func TestExactNumerics(t *testing.T) {
+	var f *float64
+	require.NoError(t, sqlnull.New(&f).Scan("0.1"))
+
+	config := sqlnull.NewConfig(sqlnull.WithExactNumerics())
+	require.ErrorContains(t, config.New(&f).Scan("0.1"), "exactly")
+	require.ErrorContains(t, config.New(&f).Scan([]byte("12345678901234567890.12")), "exactly")
+
+	require.NoError(t, config.New(&f).Scan("1.25"))
+	require.Equal(t, 1.25, *f)
+	require.NoError(t, config.New(&f).Scan(0.1))
+
+	var f32 *float32
+	require.ErrorContains(t, config.New(&f32).Scan("16777217"), "exactly")
+
+	var s *string
+	require.NoError(t, config.New(&s).Scan("0.1"))
+	require.Equal(t, "0.1", *s)
+}
+
+func TestScientificNotation(t *testing.T) {
+	var f *float64
+	require.NoError(t, sqlnull.New(&f).Scan("1.5e10"))
+	require.Equal(t, 1.5e10, *f)
+	require.NoError(t, sqlnull.New(&f).Scan([]byte("-2.5E-3")))
+	require.Equal(t, -2.5e-3, *f)
+
+	var f32 *float32
+	require.NoError(t, sqlnull.New(&f32).Scan("3e2"))
+	require.Equal(t, float32(300), *f32)
+
+	require.Error(t, sqlnull.New(&f).Scan(" 1.5e10 "))
+	tolerant := sqlnull.NewConfig(sqlnull.WithTolerantNumbers())
+	require.NoError(t, tolerant.New(&f).Scan(" +1.5e+10 "))
+	require.Equal(t, 1.5e10, *f)
+
+	exact := sqlnull.NewConfig(sqlnull.WithExactNumerics())
+	require.NoError(t, exact.New(&f).Scan("1.25e2"))
+	require.Equal(t, 125.0, *f)
+	require.ErrorContains(t, exact.New(&f).Scan("1e-1"), "exactly")
+
+	var amount *big.Rat
+	require.NoError(t, sqlnull.New(&amount).Scan("1.5e10"))
+	require.Equal(t, "15000000000", amount.RatString())
+}
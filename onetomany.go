@@ -0,0 +1,123 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ScanOneToMany reads a joined result set into parents P, each holding the child rows C
+// joined to it, e.g. orders with their items. Columns whose name starts with childPrefix
+// fill a C with the prefix stripped, the others fill a P like ScanStruct does. Rows are
+// grouped by the value of the parentKey column, keeping parents in order of first
+// appearance, and children returns the slice of a parent the children are appended to.
+// A row whose child columns are all NULL, as a LEFT JOIN yields for a parent without
+// children, adds no child. rows is always closed on return.
+//
+//	rows, err := db.Query(`SELECT o.id, o.customer, i.sku AS "item.sku", i.qty AS "item.qty"
+//		FROM orders o LEFT JOIN items i ON i.order_id = o.id`)
+//	orders, err := sqlnull.ScanOneToMany(rows, "id", "item.", func(o *Order) *[]Item { return &o.Items })
+func ScanOneToMany[P, C any](rows *sql.Rows, parentKey, childPrefix string, children func(*P) *[]C) ([]P, error) {
+	defer rows.Close()
+	c := Default()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	parentType, childType := reflect.TypeOf((*P)(nil)).Elem(), reflect.TypeOf((*C)(nil)).Elem()
+	if parentType.Kind() != reflect.Struct || childType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ScanOneToMany needs struct types, got %s and %s", parentType, childType)
+	}
+	parentFields, childFields := structFields(parentType), structFields(childType)
+
+	keyIndex := -1
+	for i, column := range columns {
+		if column == parentKey {
+			keyIndex = i
+		}
+	}
+	if keyIndex < 0 {
+		return nil, fmt.Errorf("ScanOneToMany: key column %q not in result set", parentKey)
+	}
+
+	var parents []P
+	index := make(map[any]int)
+	for rows.Next() {
+		var parent P
+		var child C
+		var key Raw
+		raws := make([]Raw, len(columns))
+		childTargets := make(map[int]any)
+
+		parentVal, childVal := reflect.ValueOf(&parent).Elem(), reflect.ValueOf(&child).Elem()
+		targets := make([]any, len(columns))
+		for i, column := range columns {
+			if name, ok := strings.CutPrefix(column, childPrefix); ok {
+				target, err := c.columnTarget(childVal, childFields, i, column, name)
+				if err != nil {
+					return nil, err
+				}
+				if target == nil {
+					return nil, fmt.Errorf("missing destination for column %q in %s", column, childType)
+				}
+				// child columns are converted once the row is known to hold a child
+				targets[i], childTargets[i] = &raws[i], target
+				continue
+			}
+
+			target, err := c.columnTarget(parentVal, parentFields, i, column, column)
+			if err != nil {
+				return nil, err
+			}
+			if target == nil {
+				return nil, fmt.Errorf("missing destination for column %q in %s", column, parentType)
+			}
+			if i == keyIndex {
+				target = c.Tee(&key, target)
+			}
+			targets[i] = target
+		}
+
+		if err := c.scanRow(rows.Scan, targets, columns, nil); err != nil {
+			return nil, err
+		}
+
+		k := key.Interface()
+		if b, ok := k.([]byte); ok {
+			k = string(b)
+		}
+		pos, ok := index[k]
+		if !ok {
+			if err := derive(parentVal.Addr(), parentFields); err != nil {
+				return nil, err
+			}
+			pos = len(parents)
+			index[k] = pos
+			parents = append(parents, parent)
+		}
+		hasChild := false
+		for i := range childTargets {
+			hasChild = hasChild || !raws[i].IsNull()
+		}
+		if hasChild {
+			for i, target := range childTargets {
+				if err := c.scan(target, raws[i].Interface()); err != nil {
+					return nil, &ColumnError{Index: i, Column: columns[i], Err: err}
+				}
+			}
+			if err := derive(childVal.Addr(), childFields); err != nil {
+				return nil, err
+			}
+			list := children(&parents[pos])
+			*list = append(*list, child)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return parents, rows.Close()
+}
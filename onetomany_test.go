@@ -0,0 +1,71 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanOneToMany(t *testing.T) {
+	type Item struct {
+		SKU  string
+		Qty  *int64
+		Note *string
+	}
+	type Order struct {
+		ID       int64
+		Customer string
+		Items    []Item `db:"-"`
+	}
+
+	db := makeusers(t)
+	_, err := db.Exec(`
+		CREATE TABLE orders (id INTEGER, customer TEXT);
+		CREATE TABLE items (order_id INTEGER, sku TEXT, qty INTEGER, note TEXT);
+		INSERT INTO orders VALUES (1, 'john'), (2, 'jane'), (3, 'joe');
+		INSERT INTO items VALUES (1, 'apple', 2, NULL), (3, 'pear', NULL, 'ripe'), (1, 'plum', 1, NULL);
+	`)
+	require.NoError(t, err)
+
+	rows, err := db.Query(`SELECT o.id, o.customer, i.sku AS "item.sku", i.qty AS "item.qty", i.note AS "item.note"
+		FROM orders o LEFT JOIN items i ON i.order_id = o.id ORDER BY o.id, i.sku`)
+	require.NoError(t, err)
+
+	orders, err := sqlnull.ScanOneToMany(rows, "id", "item.", func(o *Order) *[]Item { return &o.Items })
+	require.NoError(t, err)
+	require.Equal(t, []Order{
+		{ID: 1, Customer: "john", Items: []Item{{SKU: "apple", Qty: sqlnull.Ptr[int64](2)}, {SKU: "plum", Qty: sqlnull.Ptr[int64](1)}}},
+		{ID: 2, Customer: "jane"},
+		{ID: 3, Customer: "joe", Items: []Item{{SKU: "pear", Note: sqlnull.Ptr("ripe")}}},
+	}, orders)
+
+	rows, err = db.Query(`SELECT id, customer, 1 AS "item.unknown" FROM orders`)
+	require.NoError(t, err)
+	_, err = sqlnull.ScanOneToMany(rows, "id", "item.", func(o *Order) *[]Item { return &o.Items })
+	require.ErrorContains(t, err, `missing destination for column "item.unknown"`)
+}
+
+func TestScanOneToManyStrict(t *testing.T) {
+	type Item struct {
+		SKU string
+	}
+	type Order struct {
+		ID    int64
+		Items []Item `db:"-"`
+	}
+
+	sqlnull.SetDefault(sqlnull.NewConfig(sqlnull.WithVersion(2)))
+	t.Cleanup(func() { sqlnull.SetDefault(nil) })
+
+	db := makeusers(t)
+	rows, err := db.Query(`SELECT 1 AS id, NULL AS "item.sku"`)
+	require.NoError(t, err)
+
+	// NULL child columns of a LEFT JOIN are not converted, so ErrNull does not apply
+	orders, err := sqlnull.ScanOneToMany(rows, "id", "item.", func(o *Order) *[]Item { return &o.Items })
+	require.NoError(t, err)
+	require.Equal(t, []Order{{ID: 1}}, orders)
+}
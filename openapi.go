@@ -0,0 +1,121 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// OpenAPISchema returns the OpenAPI 3 schema of the type of v, following the package's
+// conventions: pointer fields and sql.Null wrappers are marked `nullable: true`.
+// Struct properties are named after their `json` tag, and fields without omitempty are
+// listed as required. The result can be marshaled to JSON or YAML as-is.
+func OpenAPISchema(v any) map[string]any {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return map[string]any{}
+	}
+	return openAPISchema(t, map[reflect.Type]bool{})
+}
+
+// nullTypes maps the sql.Null wrappers to the type of the value they hold.
+var nullTypes = map[reflect.Type]reflect.Type{
+	reflect.TypeOf(sql.NullBool{}):    reflect.TypeOf(false),
+	reflect.TypeOf(sql.NullByte{}):    reflect.TypeOf(byte(0)),
+	reflect.TypeOf(sql.NullInt16{}):   reflect.TypeOf(int16(0)),
+	reflect.TypeOf(sql.NullInt32{}):   reflect.TypeOf(int32(0)),
+	reflect.TypeOf(sql.NullInt64{}):   reflect.TypeOf(int64(0)),
+	reflect.TypeOf(sql.NullFloat64{}): reflect.TypeOf(float64(0)),
+	reflect.TypeOf(sql.NullString{}):  reflect.TypeOf(""),
+	reflect.TypeOf(sql.NullTime{}):    reflect.TypeOf(time.Time{}),
+}
+
+// openAPISchema builds the schema for t. Types already being built are emitted as
+// an empty schema, so recursive types terminate.
+func openAPISchema(t reflect.Type, seen map[reflect.Type]bool) map[string]any {
+	if t.Kind() == reflect.Ptr {
+		schema := openAPISchema(t.Elem(), seen)
+		schema["nullable"] = true
+		return schema
+	}
+	if elem, ok := nullTypes[t]; ok {
+		schema := openAPISchema(elem, seen)
+		schema["nullable"] = true
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32", "minimum": 0}
+	case reflect.Uint, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case reflect.Float32:
+		return map[string]any{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]any{"type": "number", "format": "double"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": openAPISchema(t.Elem(), seen)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": openAPISchema(t.Elem(), seen)}
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return map[string]any{"type": "string", "format": "date-time"}
+		}
+		if seen[t] {
+			return map[string]any{}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		properties := map[string]any{}
+		var required []string
+		openAPIProperties(t, seen, properties, &required)
+		schema := map[string]any{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	}
+	return map[string]any{}
+}
+
+// openAPIProperties adds the properties of struct type t, flattening untagged embedded structs.
+func openAPIProperties(t reflect.Type, seen map[reflect.Type]bool, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup("json")
+		if field.Anonymous && !hasTag && field.Type.Kind() == reflect.Struct {
+			openAPIProperties(field.Type, seen, properties, required)
+			continue
+		}
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = openAPISchema(field.Type, seen)
+		if !strings.Contains(","+options+",", ",omitempty,") {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type APIAddress struct {
+	City string `json:"city"`
+}
+
+type APICustomer struct {
+	ID         int64          `json:"id"`
+	Username   string         `json:"username"`
+	Phone      *string        `json:"phone,omitempty"`
+	VerifiedAt *time.Time     `json:"verified_at"`
+	Score      sql.NullInt32  `json:"score"`
+	Tags       []string       `json:"tags"`
+	Address    *APIAddress    `json:"address"`
+	Referrer   *APICustomer   `json:"referrer"`
+	Secret     string         `json:"-"`
+	Avatar     []byte         `json:"avatar,omitempty"`
+	Extra      map[string]any `json:"extra,omitempty"`
+}
+
+func TestOpenAPISchema(t *testing.T) {
+	schema := sqlnull.OpenAPISchema(&APICustomer{})
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer", "format": "int64"},
+			"username": {"type": "string"},
+			"phone": {"type": "string", "nullable": true},
+			"verified_at": {"type": "string", "format": "date-time", "nullable": true},
+			"score": {"type": "integer", "format": "int32", "nullable": true},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"address": {
+				"type": "object",
+				"properties": {"city": {"type": "string"}},
+				"required": ["city"],
+				"nullable": true
+			},
+			"referrer": {"nullable": true},
+			"avatar": {"type": "string", "format": "byte"},
+			"extra": {"type": "object", "additionalProperties": {}}
+		},
+		"required": ["id", "username", "verified_at", "score", "tags", "address", "referrer"]
+	}`, string(data))
+}
@@ -0,0 +1,126 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+)
+
+// Optional holds a value that is either absent, explicitly NULL or present, for inputs
+// where "not provided" and "provided as null" mean different things, such as PATCH bodies
+// and list filters. It implements sql.Scanner, driver.Valuer and JSON (un)marshaling;
+// a JSON null or SQL NULL sets Set but leaves Valid false.
+type Optional[T any] struct {
+	V     T
+	Valid bool // V holds a value
+	Set   bool // a value or NULL was provided
+}
+
+// optional is implemented by Optional, letting the reflection based helpers unwrap it.
+type optional interface {
+	optional() (any, bool)
+}
+
+// optional implements the optional interface for Optional.
+func (o Optional[T]) optional() (any, bool) {
+	return o.V, o.Valid
+}
+
+var optionalType = reflect.TypeOf((*optional)(nil)).Elem()
+
+// Some returns an Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{V: v, Valid: true, Set: true}
+}
+
+// IsNull reports whether o was provided as NULL.
+func (o Optional[T]) IsNull() bool {
+	return o.Set && !o.Valid
+}
+
+// IsZero reports whether o was not provided, so `json:",omitzero"` omits it.
+func (o Optional[T]) IsZero() bool {
+	return !o.Set
+}
+
+// Scan implements the sql.Scanner interface for Optional, converting src like Scan does.
+func (o *Optional[T]) Scan(src any) error {
+	if err := Default().scan(&o.V, src); err != nil {
+		return err
+	}
+	o.Valid, o.Set = src != nil, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface for Optional. Both NULL and absent values
+// are written as NULL.
+func (o Optional[T]) Value() (driver.Value, error) {
+	if !o.Valid {
+		return nil, nil
+	}
+	v, err := Default().driverValue(o.V)
+	if err != nil {
+		return nil, err
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Optional.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.V)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Optional. It is only called
+// for keys present in the input, which is what marks o as Set.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	o.V, o.Valid, o.Set = zero, false, true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(data, &o.V); err != nil {
+		return err
+	}
+	o.Valid = true
+	return nil
+}
+
+// MarshalMsgpack implements the msgpack.Marshaler interface for Optional, encoding NULL and
+// absent values as nil. It requires the nullmsgpack package to be imported.
+func (o Optional[T]) MarshalMsgpack() ([]byte, error) {
+	return marshalNullable("msgpack", o.V, o.Valid)
+}
+
+// UnmarshalMsgpack implements the msgpack.Unmarshaler interface for Optional. The msgpack
+// decoder resets the field itself on nil without calling it, so a nil decodes as absent
+// rather than NULL.
+func (o *Optional[T]) UnmarshalMsgpack(data []byte) error {
+	v, valid, err := unmarshalNullable[T]("msgpack", data)
+	if err != nil {
+		return err
+	}
+	o.V, o.Valid, o.Set = v, valid, true
+	return nil
+}
+
+// MarshalCBOR implements the cbor.Marshaler interface for Optional, encoding NULL and
+// absent values as null. It requires the nullcbor package to be imported.
+func (o Optional[T]) MarshalCBOR() ([]byte, error) {
+	return marshalNullable("cbor", o.V, o.Valid)
+}
+
+// UnmarshalCBOR implements the cbor.Unmarshaler interface for Optional.
+func (o *Optional[T]) UnmarshalCBOR(data []byte) error {
+	v, valid, err := unmarshalNullable[T]("cbor", data)
+	if err != nil {
+		return err
+	}
+	o.V, o.Valid, o.Set = v, valid, true
+	return nil
+}
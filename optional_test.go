@@ -0,0 +1,46 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionalScan(t *testing.T) {
+	db := makeusers(t)
+
+	var id sqlnull.Optional[int64]
+	var lastName sqlnull.Optional[string]
+	var verified sqlnull.Optional[string]
+	require.NoError(t, db.QueryRow("SELECT id, last_name, verified_at FROM users WHERE id=1").Scan(&id, &lastName, &verified))
+	require.Equal(t, sqlnull.Some[int64](1), id)
+	require.Equal(t, sqlnull.Some("doe"), lastName)
+	require.True(t, verified.IsNull())
+
+	v, err := verified.Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+	v, err = sqlnull.Some(int32(5)).Value()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), v)
+}
+
+func TestOptionalJSON(t *testing.T) {
+	var patch struct {
+		Username sqlnull.Optional[string] `json:"username"`
+		Phone    sqlnull.Optional[string] `json:"phone"`
+		Age      sqlnull.Optional[int]    `json:"age,omitzero"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"username": "johndoe", "phone": null}`), &patch))
+	require.Equal(t, sqlnull.Some("johndoe"), patch.Username)
+	require.True(t, patch.Phone.IsNull())
+	require.False(t, patch.Age.Set)
+
+	b, err := json.Marshal(patch)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"username": "johndoe", "phone": null}`, string(b))
+}
@@ -0,0 +1,55 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"reflect"
+)
+
+// isOptionType reports whether t is a functional Option type such as mo.Option[T] from
+// github.com/samber/mo: a struct with a Get() (T, bool) method reporting whether it holds a value.
+func isOptionType(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	method, ok := t.MethodByName("Get")
+	return ok && method.Type.NumIn() == 1 && method.Type.NumOut() == 2 && method.Type.Out(1).Kind() == reflect.Bool
+}
+
+// optionValue returns the value held by val, an Option type, and whether it holds one.
+func optionValue(val reflect.Value) (any, bool) {
+	out := val.MethodByName("Get").Call(nil)
+	return out[0].Interface(), out[1].Bool()
+}
+
+// optionTarget returns a scanner for target when it points to an Option type implementing
+// sql.Scanner, such as *mo.Option[T], and the value type is one Target supports. The column
+// is converted to the value type with the options of c, then handed to the Option's own
+// Scan, so NULL becomes None and anything else Some. It returns nil for other targets.
+func (c *Config) optionTarget(target any) sql.Scanner {
+	targetType := reflect.TypeOf(target)
+	if targetType.Kind() != reflect.Ptr || !isOptionType(targetType.Elem()) {
+		return nil
+	}
+	scanner, ok := target.(sql.Scanner)
+	if !ok {
+		return nil
+	}
+	method, _ := targetType.Elem().MethodByName("Get")
+	valueType := method.Type.Out(0)
+	if _, _, err := c.validate(reflect.New(reflect.PointerTo(valueType)).Interface()); err != nil {
+		return nil
+	}
+
+	return scanFunc(func(src any) error {
+		if src == nil {
+			return scanner.Scan(nil)
+		}
+		v := reflect.New(valueType)
+		if err := c.scan(v.Interface(), src); err != nil {
+			return err
+		}
+		return scanner.Scan(v.Elem().Interface())
+	})
+}
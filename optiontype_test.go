@@ -0,0 +1,87 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+// Option mimics the Option types of functional libraries such as Option[T] from
+// github.com/samber/mo, scanning NULL into None and anything else into Some.
+type Option[T any] struct {
+	value   T
+	present bool
+}
+
+func Some[T any](value T) Option[T] { return Option[T]{value: value, present: true} }
+func None[T any]() Option[T]        { return Option[T]{} }
+
+func (o Option[T]) Get() (T, bool) { return o.value, o.present }
+
+func (o *Option[T]) Scan(src any) error {
+	if src == nil {
+		*o = None[T]()
+		return nil
+	}
+	value, ok := src.(T)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into Option[%T]", src, o.value)
+	}
+	*o = Some(value)
+	return nil
+}
+
+func (o Option[T]) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return o.value, nil
+}
+
+func TestOptionType(t *testing.T) {
+	type User struct {
+		ID         Option[int32]
+		FirstName  Option[string]
+		LastName   Option[string]
+		VerifiedAt Option[time.Time]
+	}
+
+	db := makeusers(t)
+	rows, err := db.Query("SELECT id, first_name, last_name, verified_at FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		var user User
+		require.NoError(t, sqlnull.ScanStruct(rows, &user))
+		users = append(users, user)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []User{
+		{ID: Some[int32](1), FirstName: Some("john"), LastName: Some("doe")},
+		{ID: Some[int32](2), FirstName: Some("jane")},
+	}, users)
+
+	// the conversions of the Config apply before the Option is set
+	var id Option[int64]
+	require.NoError(t, db.QueryRow("SELECT ' 42 '").Scan(sqlnull.NewConfig(sqlnull.WithTolerantNumbers()).Target(&id)))
+	require.Equal(t, Some[int64](42), id)
+
+	at := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	query, args, err := sqlnull.NewConfig(sqlnull.WithArgTimeLayout(time.DateOnly)).Insert("users", &User{
+		ID:         Some[int32](3),
+		FirstName:  Some("joe"),
+		LastName:   None[string](),
+		VerifiedAt: Some(at),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO users (id, first_name, last_name, verified_at) VALUES (?, ?, ?, ?)", query)
+	require.Equal(t, []any{int32(3), "joe", nil, "2024-08-01"}, args)
+}
@@ -0,0 +1,129 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ParseFunc converts a non-NULL driver value into the value of a struct field. The result
+// is assigned to the field, or to the value it points to, converting it when needed.
+type ParseFunc func(src any) (any, error)
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = map[string]ParseFunc{
+		"rfc3339":  timeParser(time.RFC3339),
+		"date":     timeParser(time.DateOnly),
+		"datetime": timeParser(time.DateTime),
+		"unix":     parseUnix,
+	}
+)
+
+// RegisterParser registers fn under name, so struct fields tagged `db:"column,parse=name"`
+// are converted with it by ScanStruct. This keeps per-field parsing rules, such as date
+// formats or custom enums, on the model instead of at every call site. The built-in
+// parsers are rfc3339, date (2006-01-02), datetime (2006-01-02 15:04:05) and unix (seconds).
+func RegisterParser(name string, fn ParseFunc) {
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+	parsers[name] = fn
+}
+
+// parsedTarget returns a scanner converting a column with the parser registered under name into target.
+func parsedTarget(name string, target any) (any, error) {
+	parsersMu.RLock()
+	fn, ok := parsers[name]
+	parsersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown parse function %q", name)
+	}
+
+	return scanFunc(func(src any) error {
+		dst := reflect.ValueOf(target).Elem()
+		if src == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		v, err := fn(src)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", name, err)
+		}
+		return assign(dst, v)
+	}), nil
+}
+
+// assign stores v into dst, allocating dst when it is a pointer and converting v when needed.
+func assign(dst reflect.Value, v any) error {
+	val := reflect.ValueOf(v)
+	if !val.IsValid() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr && !val.Type().AssignableTo(dst.Type()) {
+		ptr := reflect.New(dst.Type().Elem())
+		if err := assign(ptr.Elem(), v); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	}
+
+	switch {
+	case val.Type().AssignableTo(dst.Type()):
+		dst.Set(val)
+	case val.Type().ConvertibleTo(dst.Type()):
+		dst.Set(val.Convert(dst.Type()))
+	default:
+		return fmt.Errorf("cannot assign %s to %s", val.Type(), dst.Type())
+	}
+	return nil
+}
+
+// text returns the text form of string and []byte driver values.
+func text(src any) (string, bool) {
+	switch v := src.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	}
+	return "", false
+}
+
+// timeParser returns a ParseFunc parsing text with layout. time.Time values pass through.
+func timeParser(layout string) ParseFunc {
+	return func(src any) (any, error) {
+		if t, ok := src.(time.Time); ok {
+			return t, nil
+		}
+		s, ok := text(src)
+		if !ok {
+			return nil, fmt.Errorf("cannot parse %T value as time", src)
+		}
+		return time.Parse(layout, s)
+	}
+}
+
+// parseUnix reads seconds since the Unix epoch from integer or text values.
+func parseUnix(src any) (any, error) {
+	switch v := src.(type) {
+	case int64:
+		return time.Unix(v, 0), nil
+	case float64:
+		return time.Unix(0, int64(v*float64(time.Second))), nil
+	}
+	s, ok := text(src)
+	if !ok {
+		return nil, fmt.Errorf("cannot parse %T value as unix time", src)
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return time.Unix(n, 0), nil
+}
@@ -0,0 +1,78 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type Status int
+
+const (
+	StatusActive Status = iota + 1
+	StatusBlocked
+)
+
+func init() {
+	sqlnull.RegisterParser("status", func(src any) (any, error) {
+		s, _ := src.(string)
+		switch strings.ToLower(s) {
+		case "active":
+			return StatusActive, nil
+		case "blocked":
+			return StatusBlocked, nil
+		}
+		return nil, fmt.Errorf("unknown status %v", src)
+	})
+}
+
+func TestParseTag(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`
+		CREATE TABLE [tokens] ([id] INTEGER, [expires_at] TEXT, [issued_at] INTEGER, [status] TEXT);
+		INSERT INTO tokens VALUES (1, '2024-05-01T10:30:00Z', 1714559400, 'Active'), (2, NULL, NULL, 'unknown');
+	`)
+	require.NoError(t, err)
+
+	type Token struct {
+		ID        int64
+		ExpiresAt *time.Time `db:"expires_at,parse=rfc3339"`
+		IssuedAt  time.Time  `db:"issued_at,parse=unix"`
+		Status    *Status    `db:"status,parse=status"`
+	}
+
+	rows, err := db.Query("SELECT id, expires_at, issued_at, status FROM tokens ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var token Token
+	require.True(t, rows.Next())
+	require.NoError(t, sqlnull.ScanStruct(rows, &token))
+	require.True(t, time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC).Equal(*token.ExpiresAt))
+	require.True(t, token.ExpiresAt.Equal(token.IssuedAt))
+	require.Equal(t, StatusActive, *token.Status)
+
+	require.True(t, rows.Next())
+	require.ErrorContains(t, sqlnull.ScanStruct(rows, &token), "unknown status")
+	require.NoError(t, rows.Close())
+
+	type Broken struct {
+		ID int64 `db:"id,parse=missing"`
+	}
+	rows, err = db.Query("SELECT id FROM tokens")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+	require.ErrorContains(t, sqlnull.ScanStruct(rows, &Broken{}), `unknown parse function "missing"`)
+}
@@ -0,0 +1,108 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// ApplyMergePatch applies the JSON merge patch (RFC 7386) in patch to the struct pointed
+// to by dst. Keys match fields by `json` tag, `db` tag or field name. Fields absent from
+// the patch are left untouched, nested objects are merged into struct fields, and an
+// explicit null clears the field: pointers become nil, sql.Null types become invalid and
+// Optional fields become Set but not Valid, so they can still be told apart from fields
+// the patch did not mention.
+//
+//	var user struct {
+//		Username string
+//		Phone    sqlnull.Optional[string]
+//	}
+//	err := sqlnull.ApplyMergePatch(&user, []byte(`{"phone": null}`))
+func ApplyMergePatch(dst any, patch []byte) error {
+	val := reflect.ValueOf(dst)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ApplyMergePatch destination must be a non-nil pointer to struct, got %T", dst)
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &m); err != nil {
+		return fmt.Errorf("merge patch must be a JSON object: %w", err)
+	}
+	return mergePatch(val.Elem(), m)
+}
+
+// mergePatch applies the members of a merge patch object to the struct dst.
+func mergePatch(dst reflect.Value, m map[string]json.RawMessage) error {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := structFields(dst.Type())
+	for _, key := range keys {
+		for _, field := range fields {
+			if !field.match(key) && jsonName(dst.Type().FieldByIndex(field.index)) != key {
+				continue
+			}
+			if err := mergeValue(dst.FieldByIndex(field.index), m[key]); err != nil {
+				return fmt.Errorf("key %q: %w", key, err)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// mergeValue applies a single merge patch member to dst.
+func mergeValue(dst reflect.Value, raw json.RawMessage) error {
+	raw = bytes.TrimSpace(raw)
+	custom := reflect.PointerTo(dst.Type()).Implements(jsonUnmarshalerType)
+
+	if bytes.Equal(raw, []byte("null")) && !custom {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	target := dst
+	if target.Kind() == reflect.Ptr && !custom {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		target = target.Elem()
+		custom = reflect.PointerTo(target.Type()).Implements(jsonUnmarshalerType)
+	}
+
+	switch {
+	case custom:
+	case isNullWrapper(target.Type()):
+		if err := json.Unmarshal(raw, target.Field(0).Addr().Interface()); err != nil {
+			return err
+		}
+		target.Field(1).SetBool(true)
+		return nil
+	case target.Kind() == reflect.Struct && len(raw) > 0 && raw[0] == '{':
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		return mergePatch(target, m)
+	}
+	return json.Unmarshal(raw, target.Addr().Interface())
+}
+
+// jsonName returns the name given to field by its `json` tag, if any.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,63 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type patchAddress struct {
+	City    *string `json:"city"`
+	Country string  `json:"country"`
+}
+
+type patchUser struct {
+	Username   string                   `json:"username"`
+	Phone      *string                  `json:"phone"`
+	Email      sql.NullString           `json:"email"`
+	Nickname   sqlnull.Optional[string] `json:"nick"`
+	VerifiedAt *time.Time               `json:"verified_at"`
+	Address    *patchAddress            `json:"address"`
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	user := patchUser{
+		Username: "johndoe",
+		Phone:    sqlnull.Ptr("123456789"),
+		Email:    sql.NullString{String: "john@example.com", Valid: true},
+		Address:  &patchAddress{City: sqlnull.Ptr("Jakarta"), Country: "ID"},
+	}
+
+	require.NoError(t, sqlnull.ApplyMergePatch(&user, []byte(`{
+		"phone": null,
+		"email": "jd@example.com",
+		"nick": null,
+		"verified_at": "2024-08-01T10:00:00Z",
+		"address": {"city": null},
+		"unknown": 1
+	}`)))
+	require.Equal(t, patchUser{
+		Username:   "johndoe",
+		Email:      sql.NullString{String: "jd@example.com", Valid: true},
+		Nickname:   sqlnull.Optional[string]{Set: true},
+		VerifiedAt: sqlnull.Ptr(time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)),
+		Address:    &patchAddress{Country: "ID"},
+	}, user)
+
+	require.NoError(t, sqlnull.ApplyMergePatch(&user, []byte(`{"email": null, "address": null, "Username": "jdoe"}`)))
+	require.Equal(t, "jdoe", user.Username)
+	require.False(t, user.Email.Valid)
+	require.Nil(t, user.Address)
+}
+
+func TestApplyMergePatchErrors(t *testing.T) {
+	var user patchUser
+	require.Error(t, sqlnull.ApplyMergePatch(user, []byte(`{}`)))
+	require.Error(t, sqlnull.ApplyMergePatch(&user, []byte(`[1]`)))
+	require.ErrorContains(t, sqlnull.ApplyMergePatch(&user, []byte(`{"phone": 1}`)), `key "phone"`)
+}
@@ -0,0 +1,139 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"cmp"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"runtime/metrics"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// ColumnStats holds the conversion cost of a column recorded by a Profile.
+type ColumnStats struct {
+	Column   string        // column name, or "#index" when unknown
+	Target   string        // Go type of the scan target
+	Scans    int64         // number of values converted
+	Nulls    int64         // number of NULL values among them
+	Duration time.Duration // total time spent converting
+	Allocs   uint64        // heap allocations made while converting
+}
+
+// Profile records per-column conversion time and allocation counts across a scan session,
+// to find the columns and types that make a row loop slow. A Profile is safe for
+// concurrent use.
+//
+// Allocations are read from the process-wide runtime counters, so they are only accurate
+// when nothing else allocates during the profiled scans.
+type Profile struct {
+	mu    sync.Mutex
+	stats map[profileKey]*ColumnStats
+}
+
+type profileKey struct {
+	column string
+	target reflect.Type
+}
+
+// WithProfile records the conversion cost of every column scanned through Scanner or
+// ScanStruct into profile. Like WithTrace, targets left for database/sql to handle are
+// not recorded. Profiling adds overhead of its own, so it is meant for diagnosis only.
+//
+//	profile := &sqlnull.Profile{}
+//	config := sqlnull.NewConfig(sqlnull.WithProfile(profile))
+//	// ... scan rows with config
+//	fmt.Print(profile)
+func WithProfile(profile *Profile) Option {
+	return func(c *Config) {
+		c.profile = profile
+	}
+}
+
+// Report returns the recorded statistics, ranked by total conversion time, slowest first.
+func (p *Profile) Report() []ColumnStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	report := make([]ColumnStats, 0, len(p.stats))
+	for _, stats := range p.stats {
+		report = append(report, *stats)
+	}
+	slices.SortFunc(report, func(a, b ColumnStats) int {
+		if c := cmp.Compare(b.Duration, a.Duration); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Column, b.Column)
+	})
+	return report
+}
+
+// Reset discards the recorded statistics.
+func (p *Profile) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stats = nil
+}
+
+// String formats the ranked report as a table.
+func (p *Profile) String() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COLUMN\tTARGET\tSCANS\tNULLS\tTOTAL\tPER SCAN\tALLOCS")
+	for _, stats := range p.Report() {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%d\n", stats.Column, stats.Target, stats.Scans, stats.Nulls,
+			stats.Duration, stats.Duration/time.Duration(max(stats.Scans, 1)), stats.Allocs)
+	}
+	w.Flush()
+	return b.String()
+}
+
+func (p *Profile) add(column string, target reflect.Type, null bool, d time.Duration, allocs uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := profileKey{column: column, target: target}
+	stats, ok := p.stats[key]
+	if !ok {
+		if p.stats == nil {
+			p.stats = make(map[profileKey]*ColumnStats)
+		}
+		stats = &ColumnStats{Column: column, Target: fmt.Sprint(target)}
+		p.stats[key] = stats
+	}
+	stats.Scans++
+	if null {
+		stats.Nulls++
+	}
+	stats.Duration += d
+	stats.Allocs += allocs
+}
+
+// heapAllocsMetric counts the heap allocations made by the process so far.
+const heapAllocsMetric = "/gc/heap/allocs:objects"
+
+// profiled wraps s so the cost of its conversion into target is recorded in the profile of c.
+func (c *Config) profiled(index int, column string, target any, s sql.Scanner) sql.Scanner {
+	if column == "" {
+		column = fmt.Sprintf("#%d", index)
+	}
+	targetType := reflect.TypeOf(target)
+	return scanFunc(func(src any) error {
+		sample := []metrics.Sample{{Name: heapAllocsMetric}}
+		metrics.Read(sample)
+		allocs := sample[0].Value.Uint64()
+		start := time.Now()
+
+		err := s.Scan(src)
+
+		d := time.Since(start)
+		metrics.Read(sample)
+		c.profile.add(column, targetType, src == nil, d, sample[0].Value.Uint64()-allocs)
+		return err
+	})
+}
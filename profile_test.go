@@ -0,0 +1,53 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile(t *testing.T) {
+	db := makeusers(t)
+	profile := &sqlnull.Profile{}
+	trace := &sqlnull.Trace{}
+	config := sqlnull.NewConfig(sqlnull.WithProfile(profile), sqlnull.WithTrace(trace))
+
+	for i := 0; i < 3; i++ {
+		rows, err := db.Query("SELECT id, first_name, last_name, verified_at FROM users ORDER BY id")
+		require.NoError(t, err)
+		for rows.Next() {
+			var user UserView
+			require.NoError(t, config.ScanStruct(rows, &user))
+		}
+		require.NoError(t, rows.Close())
+	}
+
+	var name *string
+	require.NoError(t, db.QueryRow("SELECT NULL").Scan(config.Scanner(&name)...))
+
+	report := profile.Report()
+	require.Len(t, report, 5)
+	byColumn := make(map[string]sqlnull.ColumnStats)
+	for i, stats := range report {
+		if i > 0 {
+			require.GreaterOrEqual(t, report[i-1].Duration, stats.Duration)
+		}
+		byColumn[stats.Column] = stats
+	}
+	require.Equal(t, sqlnull.ColumnStats{Column: "last_name", Target: "**string", Scans: 6, Nulls: 3,
+		Duration: byColumn["last_name"].Duration, Allocs: byColumn["last_name"].Allocs}, byColumn["last_name"])
+	require.Equal(t, int64(6), byColumn["verified_at"].Nulls)
+	require.Equal(t, "**string", byColumn["#0"].Target)
+	require.Positive(t, byColumn["first_name"].Duration)
+
+	// profiling does not change what the trace records
+	require.Contains(t, trace.String(), "via *sql.NullString")
+
+	require.True(t, strings.HasPrefix(profile.String(), "COLUMN"))
+	profile.Reset()
+	require.Empty(t, profile.Report())
+}
@@ -0,0 +1,219 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+)
+
+// RowsProxy holds a captured result set, its values, NULL pattern and column types,
+// and serves it again as driver.Rows. Caching middleware can keep a RowsProxy instead
+// of querying again, while code downstream still calls rows.Scan on a *sql.Rows.
+// A RowsProxy is never modified once captured, so it may be replayed concurrently.
+type RowsProxy struct {
+	columns []proxyColumn
+	rows    [][]driver.Value
+}
+
+// proxyColumn holds the column type information captured by NewRowsProxy.
+type proxyColumn struct {
+	name             string
+	databaseTypeName string
+	scanType         reflect.Type
+	nullable         bool
+	hasNullable      bool
+	length           int64
+	hasLength        bool
+	precision, scale int64
+	hasPrecision     bool
+}
+
+// NewRowsProxy reads the remaining rows of rows into a RowsProxy and closes rows.
+//
+//	proxy, err := sqlnull.NewRowsProxy(rows)
+//	cache.Set(key, proxy)
+//
+//	// later, on a cache hit
+//	rows, err := proxy.Open().Query("")
+func NewRowsProxy(rows *sql.Rows) (*RowsProxy, error) {
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &RowsProxy{columns: make([]proxyColumn, len(columnTypes))}
+	for i, ct := range columnTypes {
+		column := proxyColumn{
+			name:             ct.Name(),
+			databaseTypeName: ct.DatabaseTypeName(),
+			scanType:         ct.ScanType(),
+		}
+		column.nullable, column.hasNullable = ct.Nullable()
+		column.length, column.hasLength = ct.Length()
+		column.precision, column.scale, column.hasPrecision = ct.DecimalSize()
+		p.columns[i] = column
+	}
+
+	targets := make([]any, len(columnTypes))
+	for rows.Next() {
+		values := make([]any, len(columnTypes))
+		for i := range values {
+			targets[i] = &values[i]
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return nil, err
+		}
+		row := make([]driver.Value, len(values))
+		for i, v := range values {
+			row[i] = v
+		}
+		p.rows = append(p.rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return p, rows.Close()
+}
+
+// Len returns the number of captured rows.
+func (p *RowsProxy) Len() int {
+	return len(p.rows)
+}
+
+// Rows returns a driver.Rows replaying the captured rows from the start.
+func (p *RowsProxy) Rows() driver.Rows {
+	return &proxyRows{proxy: p}
+}
+
+// Open returns a database whose every query returns the captured rows, the way to get
+// a *sql.Rows back out of a RowsProxy. Exec and transactions are not supported.
+func (p *RowsProxy) Open() *sql.DB {
+	return sql.OpenDB(proxyConnector{proxy: p})
+}
+
+// proxyRows implements driver.Rows and the column type interfaces over a RowsProxy.
+type proxyRows struct {
+	proxy *RowsProxy
+	pos   int
+}
+
+func (r *proxyRows) Columns() []string {
+	columns := make([]string, len(r.proxy.columns))
+	for i, column := range r.proxy.columns {
+		columns[i] = column.name
+	}
+	return columns
+}
+
+func (r *proxyRows) Close() error {
+	return nil
+}
+
+func (r *proxyRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.proxy.rows) {
+		return io.EOF
+	}
+	for i, v := range r.proxy.rows[r.pos] {
+		// copied, so a sql.RawBytes target cannot alter the captured value
+		if b, ok := v.([]byte); ok {
+			v = bytes.Clone(b)
+		}
+		dest[i] = v
+	}
+	r.pos++
+	return nil
+}
+
+// ColumnTypeDatabaseTypeName implements driver.RowsColumnTypeDatabaseTypeName.
+func (r *proxyRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.proxy.columns[index].databaseTypeName
+}
+
+// ColumnTypeScanType implements driver.RowsColumnTypeScanType.
+func (r *proxyRows) ColumnTypeScanType(index int) reflect.Type {
+	if t := r.proxy.columns[index].scanType; t != nil {
+		return t
+	}
+	return reflect.TypeOf((*any)(nil)).Elem()
+}
+
+// ColumnTypeNullable implements driver.RowsColumnTypeNullable.
+func (r *proxyRows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	column := r.proxy.columns[index]
+	return column.nullable, column.hasNullable
+}
+
+// ColumnTypeLength implements driver.RowsColumnTypeLength.
+func (r *proxyRows) ColumnTypeLength(index int) (length int64, ok bool) {
+	column := r.proxy.columns[index]
+	return column.length, column.hasLength
+}
+
+// ColumnTypePrecisionScale implements driver.RowsColumnTypePrecisionScale.
+func (r *proxyRows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	column := r.proxy.columns[index]
+	return column.precision, column.scale, column.hasPrecision
+}
+
+// proxyConnector, proxyConn and proxyStmt serve a RowsProxy through database/sql.
+type proxyConnector struct {
+	proxy *RowsProxy
+}
+
+func (c proxyConnector) Connect(context.Context) (driver.Conn, error) {
+	return proxyConn(c), nil
+}
+
+func (c proxyConnector) Driver() driver.Driver {
+	return proxyDriver{}
+}
+
+type proxyDriver struct{}
+
+func (proxyDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("sqlnull: proxy driver must be opened with RowsProxy.Open")
+}
+
+type proxyConn struct {
+	proxy *RowsProxy
+}
+
+func (c proxyConn) Prepare(string) (driver.Stmt, error) {
+	return proxyStmt(c), nil
+}
+
+func (c proxyConn) Close() error {
+	return nil
+}
+
+func (c proxyConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("sqlnull: proxy driver does not support transactions")
+}
+
+type proxyStmt struct {
+	proxy *RowsProxy
+}
+
+func (s proxyStmt) Close() error {
+	return nil
+}
+
+func (s proxyStmt) NumInput() int {
+	return -1
+}
+
+func (s proxyStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("sqlnull: proxy driver does not support Exec")
+}
+
+func (s proxyStmt) Query([]driver.Value) (driver.Rows, error) {
+	return s.proxy.Rows(), nil
+}
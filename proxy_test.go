@@ -0,0 +1,48 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowsProxy(t *testing.T) {
+	db := makeusers(t)
+	rows, err := db.Query("SELECT id, first_name, last_name, verified_at FROM users ORDER BY id")
+	require.NoError(t, err)
+
+	proxy, err := sqlnull.NewRowsProxy(rows)
+	require.NoError(t, err)
+	require.Equal(t, 2, proxy.Len())
+
+	cached := proxy.Open()
+	defer cached.Close()
+
+	// replayed twice, each query starts from the first row
+	for i := 0; i < 2; i++ {
+		rows, err := cached.Query("")
+		require.NoError(t, err)
+
+		columnTypes, err := rows.ColumnTypes()
+		require.NoError(t, err)
+		require.Equal(t, "TEXT", columnTypes[1].DatabaseTypeName())
+
+		var users []UserView
+		for rows.Next() {
+			var user UserView
+			require.NoError(t, sqlnull.ScanStruct(rows, &user))
+			users = append(users, user)
+		}
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+		require.Len(t, users, 2)
+		require.Equal(t, "doe", *users[0].LastName)
+		require.Nil(t, users[1].LastName)
+	}
+
+	_, err = cached.Exec("DELETE FROM users")
+	require.Error(t, err)
+}
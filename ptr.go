@@ -0,0 +1,76 @@
+package sqlnull
+
+import "time"
+
+// Ptr returns a pointer to v, handy for filling nullable fields from literals:
+//
+//	user.Phone = sqlnull.Ptr("123456789")
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// Deref returns the value p points to, or the zero value of T when p is nil.
+func Deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// ValueOr returns the value p points to, or def when p is nil.
+func ValueOr[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// The typed getters below return the value p points to, or def when p is nil. Unlike
+// ValueOr they are not generic, so they can be used where generic functions cannot,
+// e.g. in a template.FuncMap.
+
+// GetBool returns *p, or def when p is nil.
+func GetBool(p *bool, def bool) bool {
+	return ValueOr(p, def)
+}
+
+// GetByte returns *p, or def when p is nil.
+func GetByte(p *byte, def byte) byte {
+	return ValueOr(p, def)
+}
+
+// GetInt16 returns *p, or def when p is nil.
+func GetInt16(p *int16, def int16) int16 {
+	return ValueOr(p, def)
+}
+
+// GetInt32 returns *p, or def when p is nil.
+func GetInt32(p *int32, def int32) int32 {
+	return ValueOr(p, def)
+}
+
+// GetInt64 returns *p, or def when p is nil.
+func GetInt64(p *int64, def int64) int64 {
+	return ValueOr(p, def)
+}
+
+// GetInt returns *p, or def when p is nil.
+func GetInt(p *int, def int) int {
+	return ValueOr(p, def)
+}
+
+// GetFloat64 returns *p, or def when p is nil.
+func GetFloat64(p *float64, def float64) float64 {
+	return ValueOr(p, def)
+}
+
+// GetString returns *p, or def when p is nil.
+func GetString(p *string, def string) string {
+	return ValueOr(p, def)
+}
+
+// GetTime returns *p, or def when p is nil.
+func GetTime(p *time.Time, def time.Time) time.Time {
+	return ValueOr(p, def)
+}
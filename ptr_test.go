@@ -0,0 +1,48 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPtrHelpers(t *testing.T) {
+	phone := sqlnull.Ptr("123456789")
+	require.Equal(t, "123456789", *phone)
+
+	require.Equal(t, "123456789", sqlnull.Deref(phone))
+	require.Equal(t, "", sqlnull.Deref[string](nil))
+	require.Equal(t, time.Time{}, sqlnull.Deref[time.Time](nil))
+
+	require.Equal(t, "123456789", sqlnull.ValueOr(phone, "n/a"))
+	require.Equal(t, "n/a", sqlnull.ValueOr(nil, "n/a"))
+	require.Equal(t, CustomInt64(7), sqlnull.ValueOr(nil, CustomInt64(7)))
+}
+
+func TestGetters(t *testing.T) {
+	var user NewSqlNullTest
+	require.Equal(t, "n/a", sqlnull.GetString(user.FieldString, "n/a"))
+	require.Equal(t, int64(-1), sqlnull.GetInt64(user.FieldInt64, -1))
+	require.Equal(t, true, sqlnull.GetBool(user.FieldBool, true))
+
+	now := time.Now()
+	require.Equal(t, now, sqlnull.GetTime(user.FieldTime, now))
+
+	user.FieldString = sqlnull.Ptr("lorem ipsum")
+	user.FieldInt32 = sqlnull.Ptr[int32](99)
+	require.Equal(t, "lorem ipsum", sqlnull.GetString(user.FieldString, "n/a"))
+	require.Equal(t, int32(99), sqlnull.GetInt32(user.FieldInt32, 0))
+
+	tmpl := template.Must(template.New("").Funcs(template.FuncMap{
+		"str": sqlnull.GetString,
+	}).Parse(`{{str .FieldString "n/a"}} {{str .FieldString "x"}}`))
+	var buf strings.Builder
+	require.NoError(t, tmpl.Execute(&buf, user))
+	require.Equal(t, "lorem ipsum lorem ipsum", buf.String())
+}
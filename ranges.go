@@ -0,0 +1,43 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// WithStrictRanges makes integer and float targets narrower than the scanned value fail
+// with an error instead of silently wrapping around, e.g. 70000 scanned into an int16 or
+// -1 scanned into a uint32. Float32 targets fail on values beyond their range.
+func WithStrictRanges() Option {
+	return func(c *Config) {
+		c.strictRanges = true
+	}
+}
+
+// checkRange returns an error when the converted value v does not fit a value of type t.
+func checkRange(v reflect.Value, t reflect.Type) error {
+	target := reflect.Zero(t)
+	overflow := false
+	switch {
+	case v.CanInt() && target.CanInt():
+		overflow = target.OverflowInt(v.Int())
+	case v.CanInt() && target.CanUint():
+		if v.Int() < 0 {
+			return fmt.Errorf("negative value %d out of range for %s", v.Int(), t)
+		}
+		overflow = target.OverflowUint(uint64(v.Int()))
+	case v.CanUint() && target.CanInt():
+		overflow = v.Uint() > math.MaxInt64 || target.OverflowInt(int64(v.Uint()))
+	case v.CanUint() && target.CanUint():
+		overflow = target.OverflowUint(v.Uint())
+	case v.CanFloat() && target.CanFloat():
+		overflow = target.OverflowFloat(v.Float())
+	}
+	if overflow {
+		return fmt.Errorf("value %v out of range for %s", v.Interface(), t)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictRanges(t *testing.T) {
+	var i8 *int8
+	require.NoError(t, sqlnull.New(&i8).Scan(int64(300)))
+	require.Equal(t, int8(44), *i8) // wraps around by default
+
+	strict := sqlnull.NewConfig(sqlnull.WithStrictRanges())
+	require.ErrorContains(t, strict.New(&i8).Scan(int64(300)), "value 300 out of range for int8")
+	require.NoError(t, strict.New(&i8).Scan(int64(-128)))
+	require.Equal(t, int8(-128), *i8)
+
+	var u32 *uint32
+	require.ErrorContains(t, strict.New(&u32).Scan(int64(-1)), "negative value -1 out of range for uint32")
+
+	var u16 *uint16
+	require.ErrorContains(t, strict.New(&u16).Scan(int64(-2)), "negative value -2 out of range for uint16")
+
+	var f32 *float32
+	require.ErrorContains(t, strict.New(&f32).Scan(1e300), "out of range for float32")
+	require.NoError(t, strict.New(&f32).Scan(1.5))
+	require.Equal(t, float32(1.5), *f32)
+
+	require.NoError(t, strict.New(&i8).Scan(nil))
+	require.Nil(t, i8)
+
+	var plain struct {
+		Small int16 `db:"small"`
+	}
+	db := makeusers(t)
+	rows, err := db.Query("SELECT 70000 AS small")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+	require.Error(t, strict.ScanStruct(rows, &plain))
+}
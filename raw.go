@@ -0,0 +1,73 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+)
+
+// Raw holds a driver value exactly as it was scanned, without any conversion.
+// It can be used as a scan destination on its own or next to a typed target with Capture.
+type Raw struct {
+	value any
+}
+
+// Scan implements the sql.Scanner interface for Raw. Byte slices are copied,
+// since the driver may reuse their buffer for the next row.
+func (r *Raw) Scan(src any) error {
+	if b, ok := src.([]byte); ok {
+		src = bytes.Clone(b)
+	}
+	r.value = src
+	return nil
+}
+
+// Value implements the driver.Valuer interface for Raw, so the captured value
+// can be written back without loss of representation.
+func (r Raw) Value() (driver.Value, error) {
+	return r.value, nil
+}
+
+// Interface returns the captured driver value, nil for NULL.
+func (r Raw) Interface() any {
+	return r.value
+}
+
+// Type returns the Go type of the captured driver value, nil for NULL.
+func (r Raw) Type() reflect.Type {
+	return reflect.TypeOf(r.value)
+}
+
+// Bytes returns the captured value when the driver delivered it as []byte or string, otherwise nil.
+func (r Raw) Bytes() []byte {
+	switch v := r.value.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	}
+	return nil
+}
+
+// IsNull reports whether the captured value was NULL.
+func (r Raw) IsNull() bool {
+	return r.value == nil
+}
+
+// Capture returns a scanner that converts a column into target and keeps the original
+// driver value in raw. The raw value is kept even when the conversion fails.
+//
+//	var amount *float64
+//	var raw sqlnull.Raw
+//	err = row.Scan(sqlnull.Capture(&amount, &raw))
+func Capture(target any, raw *Raw) sql.Scanner {
+	return Default().Capture(target, raw)
+}
+
+// Capture returns a scanner that converts a column into target and keeps the original driver value in raw.
+func (c *Config) Capture(target any, raw *Raw) sql.Scanner {
+	return c.Tee(raw, target)
+}
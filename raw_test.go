@@ -0,0 +1,36 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapture(t *testing.T) {
+	var amount *float64
+	var raw sqlnull.Raw
+
+	buf := []byte("12.50")
+	require.NoError(t, sqlnull.Capture(&amount, &raw).Scan(buf))
+	copy(buf, "99.99")
+	require.Equal(t, 12.5, *amount)
+	require.Equal(t, []byte("12.50"), raw.Bytes())
+	require.Equal(t, reflect.TypeOf([]byte(nil)), raw.Type())
+
+	value, err := raw.Value()
+	require.NoError(t, err)
+	require.Equal(t, []byte("12.50"), value)
+
+	require.NoError(t, sqlnull.Capture(&amount, &raw).Scan(nil))
+	require.Nil(t, amount)
+	require.True(t, raw.IsNull())
+	require.Nil(t, raw.Type())
+
+	var count *int64
+	require.Error(t, sqlnull.Capture(&count, &raw).Scan("lorem ipsum"))
+	require.Equal(t, "lorem ipsum", raw.Interface())
+}
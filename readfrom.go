@@ -0,0 +1,60 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"io"
+)
+
+// StreamValue is a statement argument whose content is read from an io.Reader.
+//
+// StreamValue implements io.Reader itself, so drivers that accept readers as
+// parameters can stream the content. Other drivers get the content through
+// driver.Valuer, which buffers it up to the size cap.
+type StreamValue struct {
+	r        io.Reader
+	maxBytes int64
+}
+
+// ReadFrom returns a statement argument streaming its content from r, pairing with WriteTo
+// on the scan side. A nil r is sent as NULL. When the content has to be buffered, more
+// than maxBytes bytes fail with an error wrapping ErrTooLarge; maxBytes <= 0 means no cap.
+//
+//	f, err := os.Open("avatar.png")
+//	...
+//	_, err = db.Exec("UPDATE users SET avatar=? WHERE id=?", sqlnull.ReadFrom(f, 10<<20), id)
+func ReadFrom(r io.Reader, maxBytes int64) *StreamValue {
+	return &StreamValue{
+		r:        r,
+		maxBytes: maxBytes,
+	}
+}
+
+// Read implements the io.Reader interface for StreamValue.
+func (v *StreamValue) Read(p []byte) (int, error) {
+	if v.r == nil {
+		return 0, io.EOF
+	}
+	return v.r.Read(p)
+}
+
+// Value implements the driver.Valuer interface for StreamValue, buffering the content.
+func (v *StreamValue) Value() (driver.Value, error) {
+	if v.r == nil {
+		return nil, nil
+	}
+	if v.maxBytes <= 0 {
+		return io.ReadAll(v.r)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(v.r, v.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > v.maxBytes {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrTooLarge, v.maxBytes)
+	}
+	return b, nil
+}
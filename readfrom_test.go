@@ -0,0 +1,43 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFrom(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE [files] ([id] INTEGER, [content] BLOB)`)
+	require.NoError(t, err)
+
+	_, err = db.Exec("INSERT INTO files (id, content) VALUES (?, ?)", 1, sqlnull.ReadFrom(strings.NewReader("lorem ipsum"), 64))
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO files (id, content) VALUES (?, ?)", 2, sqlnull.ReadFrom(nil, 0))
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO files (id, content) VALUES (?, ?)", 3, sqlnull.ReadFrom(strings.NewReader("lorem ipsum"), 5))
+	require.True(t, errors.Is(err, sqlnull.ErrTooLarge))
+
+	var buf bytes.Buffer
+	blob := sqlnull.WriteTo(&buf)
+	require.NoError(t, db.QueryRow("SELECT content FROM files WHERE id=1").Scan(blob))
+	require.Equal(t, "lorem ipsum", buf.String())
+	require.NoError(t, db.QueryRow("SELECT content FROM files WHERE id=2").Scan(blob))
+	require.True(t, blob.IsNull())
+
+	b, err := io.ReadAll(sqlnull.ReadFrom(strings.NewReader("streamed"), 0))
+	require.NoError(t, err)
+	require.Equal(t, "streamed", string(b))
+}
@@ -0,0 +1,484 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// recordVersion is the first byte of every encoded record, so the format can evolve
+// without misreading values cached by older versions.
+const recordVersion = 1
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	errShortRecord = errors.New("record is truncated")
+)
+
+// EncodeRecord encodes v, typically a scanned struct, into a compact binary form for caches
+// such as Redis or memcached. The encoding is deterministic, equal values always give equal
+// bytes, and every nullable field carries an explicit presence bit, so a nil pointer, an
+// invalid sql.Null type or an Optional without a value is restored as exactly that.
+//
+// Fields are encoded in declaration order without their names, so records must be decoded
+// into the type they were encoded from. Types implementing encoding.BinaryMarshaler, such as
+// time.Time, or encoding.TextMarshaler are encoded through those interfaces.
+func EncodeRecord(v any) ([]byte, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil, fmt.Errorf("cannot encode nil %T", v)
+		}
+		val = val.Elem()
+	}
+
+	e := &recordEncoder{buf: []byte{recordVersion}}
+	if err := e.encode(val); err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+// DecodeRecord decodes data produced by EncodeRecord into the value pointed to by dest.
+// Optional fields are restored with Set, since the encoding only records whether they hold
+// a value.
+func DecodeRecord(data []byte, dest any) error {
+	val := reflect.ValueOf(dest)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("DecodeRecord destination must be a non-nil pointer, got %T", dest)
+	}
+	if len(data) == 0 || data[0] != recordVersion {
+		return fmt.Errorf("unsupported record version")
+	}
+
+	val = val.Elem()
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+
+	d := &recordDecoder{data: data[1:]}
+	if err := d.decode(val); err != nil {
+		return err
+	}
+	if len(d.data) > 0 {
+		return fmt.Errorf("record has %d trailing bytes", len(d.data))
+	}
+	return nil
+}
+
+// nullable reports whether values of t may be NULL and carry a presence bit.
+func nullable(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr || isNullWrapper(t) || t.Implements(optionalType)
+}
+
+// present unwraps a nullable value, reporting false when it is NULL.
+func present(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Ptr {
+		return v.Elem(), !v.IsNil()
+	}
+	return v.Field(0), v.Field(1).Bool()
+}
+
+// implements reports whether t or a pointer to t implements the interface typ.
+func implements(t, typ reflect.Type) bool {
+	return t.Implements(typ) || reflect.PointerTo(t).Implements(typ)
+}
+
+// marshaler returns v as the interface typ when v or a pointer to v implements it.
+func marshaler(v reflect.Value, typ reflect.Type) (any, bool) {
+	if v.Type().Implements(typ) {
+		return v.Interface(), true
+	}
+	if reflect.PointerTo(v.Type()).Implements(typ) {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		return ptr.Interface(), true
+	}
+	return nil, false
+}
+
+// recordEncoder implements EncodeRecord.
+type recordEncoder struct {
+	buf []byte
+}
+
+// encode writes v, preceded by a presence byte when it is nullable.
+func (e *recordEncoder) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		return fmt.Errorf("cannot encode nil interface value")
+	}
+	if nullable(v.Type()) {
+		inner, ok := present(v)
+		if !ok {
+			e.buf = append(e.buf, 0)
+			return nil
+		}
+		e.buf = append(e.buf, 1)
+		return e.encode(inner)
+	}
+	return e.encodeValue(v)
+}
+
+// encodeValue writes the non-nullable value v.
+func (e *recordEncoder) encodeValue(v reflect.Value) error {
+	if m, ok := marshaler(v, binaryMarshalerType); ok {
+		b, err := m.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			return err
+		}
+		e.writeBytes(b)
+		return nil
+	}
+	if m, ok := marshaler(v, textMarshalerType); ok {
+		b, err := m.(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return err
+		}
+		e.writeBytes(b)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			e.buf = append(e.buf, 1)
+		} else {
+			e.buf = append(e.buf, 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.buf = binary.AppendVarint(e.buf, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.buf = binary.AppendUvarint(e.buf, v.Uint())
+	case reflect.Float32:
+		e.buf = binary.BigEndian.AppendUint32(e.buf, math.Float32bits(float32(v.Float())))
+	case reflect.Float64:
+		e.buf = binary.BigEndian.AppendUint64(e.buf, math.Float64bits(v.Float()))
+	case reflect.String:
+		e.writeBytes([]byte(v.String()))
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			e.writeBytes(v.Bytes())
+			return nil
+		}
+		e.buf = binary.AppendUvarint(e.buf, uint64(v.Len()))
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := e.encode(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		return e.encodeMap(v)
+	case reflect.Struct:
+		return e.encodeStruct(v)
+	default:
+		return fmt.Errorf("cannot encode %s", v.Type())
+	}
+	return nil
+}
+
+// encodeMap writes the entries of v sorted by their encoded keys.
+func (e *recordEncoder) encodeMap(v reflect.Value) error {
+	type entry struct{ key, value []byte }
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key, value := &recordEncoder{}, &recordEncoder{}
+		if err := key.encode(iter.Key()); err != nil {
+			return err
+		}
+		if err := value.encode(iter.Value()); err != nil {
+			return err
+		}
+		entries = append(entries, entry{key.buf, value.buf})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(entries)))
+	for _, entry := range entries {
+		e.buf = append(append(e.buf, entry.key...), entry.value...)
+	}
+	return nil
+}
+
+// encodeStruct writes the presence bits of the nullable exported fields of v, then the
+// fields themselves, skipping the NULL ones.
+func (e *recordEncoder) encodeStruct(v reflect.Value) error {
+	var bits []bool
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).IsExported() && nullable(v.Field(i).Type()) {
+			_, ok := present(v.Field(i))
+			bits = append(bits, ok)
+		}
+	}
+	bitmap := make([]byte, (len(bits)+7)/8)
+	for i, ok := range bits {
+		if ok {
+			bitmap[i/8] |= 1 << (i % 8)
+		}
+	}
+	e.buf = append(e.buf, bitmap...)
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		if nullable(field.Type()) {
+			inner, ok := present(field)
+			if !ok {
+				continue
+			}
+			field = inner
+		}
+		if err := e.encode(field); err != nil {
+			return fmt.Errorf("field %s: %w", v.Type().Field(i).Name, err)
+		}
+	}
+	return nil
+}
+
+// writeBytes writes b preceded by its length.
+func (e *recordEncoder) writeBytes(b []byte) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+// recordDecoder implements DecodeRecord.
+type recordDecoder struct {
+	data []byte
+}
+
+// decode reads into v, which is settable, honoring the presence byte of nullable types.
+func (d *recordDecoder) decode(v reflect.Value) error {
+	if nullable(v.Type()) {
+		b, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		inner := d.wrap(v, b == 1)
+		if b == 0 {
+			return nil
+		}
+		return d.decode(inner)
+	}
+	return d.decodeValue(v)
+}
+
+// wrap marks the nullable v as present or NULL and returns the value to decode into.
+func (d *recordDecoder) wrap(v reflect.Value, ok bool) reflect.Value {
+	v.Set(reflect.Zero(v.Type()))
+	if v.Kind() == reflect.Ptr {
+		if ok {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return v.Elem()
+	}
+	v.Field(1).SetBool(ok)
+	if v.Type().Implements(optionalType) {
+		v.Field(2).SetBool(true)
+	}
+	return v.Field(0)
+}
+
+// decodeValue reads the non-nullable value v.
+func (d *recordDecoder) decodeValue(v reflect.Value) error {
+	switch {
+	case implements(v.Type(), binaryMarshalerType):
+		u, ok := v.Addr().Interface().(encoding.BinaryUnmarshaler)
+		if !ok {
+			return fmt.Errorf("cannot decode %s: not an encoding.BinaryUnmarshaler", v.Type())
+		}
+		b, err := d.readBytes()
+		if err != nil {
+			return err
+		}
+		return u.UnmarshalBinary(b)
+	case implements(v.Type(), textMarshalerType):
+		u, ok := v.Addr().Interface().(encoding.TextUnmarshaler)
+		if !ok {
+			return fmt.Errorf("cannot decode %s: not an encoding.TextUnmarshaler", v.Type())
+		}
+		b, err := d.readBytes()
+		if err != nil {
+			return err
+		}
+		return u.UnmarshalText(b)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		v.SetBool(b == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, n := binary.Varint(d.data)
+		if n <= 0 {
+			return errShortRecord
+		}
+		d.data = d.data[n:]
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := d.readUvarint()
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32:
+		if len(d.data) < 4 {
+			return errShortRecord
+		}
+		v.SetFloat(float64(math.Float32frombits(binary.BigEndian.Uint32(d.data))))
+		d.data = d.data[4:]
+	case reflect.Float64:
+		if len(d.data) < 8 {
+			return errShortRecord
+		}
+		v.SetFloat(math.Float64frombits(binary.BigEndian.Uint64(d.data)))
+		d.data = d.data[8:]
+	case reflect.String:
+		b, err := d.readBytes()
+		if err != nil {
+			return err
+		}
+		v.SetString(string(b))
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, err := d.readBytes()
+			if err != nil {
+				return err
+			}
+			v.SetBytes(bytes.Clone(b))
+			return nil
+		}
+		n, err := d.readLen()
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := d.decode(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		n, err := d.readLen()
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.MakeMapWithSize(v.Type(), n))
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			value := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decode(key); err != nil {
+				return err
+			}
+			if err := d.decode(value); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, value)
+		}
+	case reflect.Struct:
+		return d.decodeStruct(v)
+	default:
+		return fmt.Errorf("cannot decode %s", v.Type())
+	}
+	return nil
+}
+
+// decodeStruct reads the fields of v written by encodeStruct.
+func (d *recordDecoder) decodeStruct(v reflect.Value) error {
+	count := 0
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).IsExported() && nullable(v.Field(i).Type()) {
+			count++
+		}
+	}
+	size := (count + 7) / 8
+	if len(d.data) < size {
+		return errShortRecord
+	}
+	bitmap := d.data[:size]
+	d.data = d.data[size:]
+
+	bit := 0
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		if nullable(field.Type()) {
+			ok := bitmap[bit/8]&(1<<(bit%8)) != 0
+			bit++
+			field = d.wrap(field, ok)
+			if !ok {
+				continue
+			}
+		}
+		if err := d.decode(field); err != nil {
+			return fmt.Errorf("field %s: %w", v.Type().Field(i).Name, err)
+		}
+	}
+	return nil
+}
+
+// readByte reads a single byte.
+func (d *recordDecoder) readByte() (byte, error) {
+	if len(d.data) < 1 {
+		return 0, errShortRecord
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b, nil
+}
+
+// readUvarint reads an unsigned varint.
+func (d *recordDecoder) readUvarint() (uint64, error) {
+	u, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		return 0, errShortRecord
+	}
+	d.data = d.data[n:]
+	return u, nil
+}
+
+// readLen reads a length, checking it against the remaining data.
+func (d *recordDecoder) readLen() (int, error) {
+	u, err := d.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	if u > uint64(len(d.data)) {
+		return 0, errShortRecord
+	}
+	return int(u), nil
+}
+
+// readBytes reads bytes preceded by their length.
+func (d *recordDecoder) readBytes() ([]byte, error) {
+	n, err := d.readLen()
+	if err != nil {
+		return nil, err
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b, nil
+}
@@ -0,0 +1,88 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type recordUser struct {
+	ID         int64
+	Username   string
+	Phone      *string
+	Email      sql.NullString
+	Age        sqlnull.Optional[int32]
+	Score      float64
+	Active     bool
+	VerifiedAt *time.Time
+	CreatedAt  time.Time
+	Balance    *big.Rat
+	Tags       []string
+	Extra      map[string]*int
+	Avatar     []byte
+	secret     string
+}
+
+func TestRecord(t *testing.T) {
+	created := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	user := recordUser{
+		ID:        1,
+		Username:  "johndoe",
+		Phone:     sqlnull.Ptr(""),
+		Age:       sqlnull.Some[int32](30),
+		Score:     1.5,
+		Active:    true,
+		CreatedAt: created,
+		Balance:   big.NewRat(1, 3),
+		Tags:      []string{"admin", "staff"},
+		Extra:     map[string]*int{"a": sqlnull.Ptr(1), "b": nil, "c": sqlnull.Ptr(0)},
+		Avatar:    []byte{1, 2, 3},
+		secret:    "hidden",
+	}
+
+	data, err := sqlnull.EncodeRecord(&user)
+	require.NoError(t, err)
+
+	// deterministic, whatever the map order
+	for i := 0; i < 10; i++ {
+		again, err := sqlnull.EncodeRecord(user)
+		require.NoError(t, err)
+		require.Equal(t, data, again)
+	}
+
+	var decoded recordUser
+	require.NoError(t, sqlnull.DecodeRecord(data, &decoded))
+	user.secret = ""
+	require.Equal(t, user, decoded)
+	require.NotNil(t, decoded.Phone)
+	require.Nil(t, decoded.VerifiedAt)
+
+	// NULL and zero values stay apart
+	decoded.Phone = nil
+	decoded.Age = sqlnull.Optional[int32]{Set: true}
+	data, err = sqlnull.EncodeRecord(decoded)
+	require.NoError(t, err)
+	require.NoError(t, sqlnull.DecodeRecord(data, &decoded))
+	require.Nil(t, decoded.Phone)
+	require.True(t, decoded.Age.IsNull())
+}
+
+func TestRecordErrors(t *testing.T) {
+	data, err := sqlnull.EncodeRecord(recordUser{ID: 1})
+	require.NoError(t, err)
+
+	var user recordUser
+	require.Error(t, sqlnull.DecodeRecord(data, user))
+	require.Error(t, sqlnull.DecodeRecord(data[:len(data)-1], &user))
+	require.Error(t, sqlnull.DecodeRecord(append(data, 0), &user))
+	require.Error(t, sqlnull.DecodeRecord([]byte{99}, &user))
+
+	_, err = sqlnull.EncodeRecord(struct{ Fn func() }{})
+	require.Error(t, err)
+}
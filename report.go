@@ -0,0 +1,94 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import "database/sql"
+
+// ColumnStatus tells what happened to a single column during a scan.
+type ColumnStatus int
+
+const (
+	// ColumnNotScanned means the scan stopped before reaching the column.
+	ColumnNotScanned ColumnStatus = iota
+	// ColumnAssigned means the column value was converted and assigned.
+	ColumnAssigned
+	// ColumnNull means the column was NULL and the target was set accordingly.
+	ColumnNull
+	// ColumnFailed means the column value could not be converted.
+	ColumnFailed
+)
+
+// String returns the name of the status.
+func (s ColumnStatus) String() string {
+	switch s {
+	case ColumnAssigned:
+		return "assigned"
+	case ColumnNull:
+		return "null"
+	case ColumnFailed:
+		return "failed"
+	}
+	return "not scanned"
+}
+
+// ColumnResult is the outcome of scanning a single column.
+type ColumnResult struct {
+	Index  int    // column position within the scan
+	Column string // column name, when known
+	Status ColumnStatus
+	Err    error // conversion error when Status is ColumnFailed
+
+	// Truncated is set when the value was cut down by WithTruncateBytes.
+	Truncated bool
+}
+
+// Report lists the outcome of every column of a scan, in column order.
+type Report []ColumnResult
+
+// OK reports whether every column was assigned or NULL.
+func (r Report) OK() bool {
+	for _, result := range r {
+		if result.Status != ColumnAssigned && result.Status != ColumnNull {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the results of the columns that could not be converted.
+func (r Report) Failed() []ColumnResult {
+	var failed []ColumnResult
+	for _, result := range r {
+		if result.Status == ColumnFailed {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// ScanReport scans like Scan but keeps going past failing columns and also returns a
+// per-column report, so calling code can decide whether a partially populated result is still usable.
+// Targets handed to database/sql untouched are only reported as assigned when the whole scan succeeds.
+func ScanReport(scan func(dest ...any) error, targets ...any) (Report, error) {
+	return Default().ScanReport(scan, targets...)
+}
+
+// ScanReport scans like Scan but keeps going past failing columns and also returns a per-column report.
+func (c *Config) ScanReport(scan func(dest ...any) error, targets ...any) (Report, error) {
+	var report Report
+	c.reset(targets...)
+	err := c.scanRow(scan, c.Scanner(targets...), nil, &report)
+	return report, err
+}
+
+// ScanStructReport scans like ScanStruct but keeps going past failing columns and also returns a per-column report.
+func ScanStructReport(rows *sql.Rows, dest any) (Report, error) {
+	return Default().ScanStructReport(rows, dest)
+}
+
+// ScanStructReport scans like ScanStruct but keeps going past failing columns and also returns a per-column report.
+func (c *Config) ScanStructReport(rows *sql.Rows, dest any) (Report, error) {
+	var report Report
+	err := c.scanStruct(rows, dest, &report)
+	return report, err
+}
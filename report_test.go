@@ -0,0 +1,52 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanReport(t *testing.T) {
+	row, err := makedatabase(true, nil, 88.89, time.Now(), "lorem ipsum")
+	require.NoError(t, err)
+
+	var flag *bool
+	var count *int64
+	var amount *float64
+	var bad *time.Time
+	var raw string
+	report, err := sqlnull.ScanReport(row.Scan, &flag, &count, &amount, &count, &count, &count, &bad, &raw, &flag)
+	require.Error(t, err)
+	require.Len(t, report, 9)
+	require.False(t, report.OK())
+
+	require.Equal(t, sqlnull.ColumnAssigned, report[0].Status)
+	require.Equal(t, sqlnull.ColumnNull, report[1].Status)
+	require.Equal(t, sqlnull.ColumnAssigned, report[2].Status)
+	require.Equal(t, sqlnull.ColumnFailed, report[6].Status)
+	require.Equal(t, "failed", report[6].Status.String())
+	require.Error(t, report[6].Err)
+	require.Len(t, report.Failed(), 1)
+	require.Equal(t, 88.89, *amount)
+}
+
+func TestScanStructReport(t *testing.T) {
+	db := makeusers(t)
+
+	rows, err := db.Query("SELECT id, first_name, last_name FROM users WHERE id=2")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	var user UserView
+	report, err := sqlnull.ScanStructReport(rows, &user)
+	require.NoError(t, err)
+	require.True(t, report.OK())
+	require.Equal(t, "last_name", report[2].Column)
+	require.Equal(t, sqlnull.ColumnNull, report[2].Status)
+	require.Equal(t, "jane", user.FullName)
+}
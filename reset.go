@@ -0,0 +1,50 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import "reflect"
+
+// WithResetTargets sets every destination to its zero value, nil for pointers, before
+// each row is scanned by Scan, ScanReport, EachCtx, ScanStruct and the Scan and ScanStruct
+// methods of Rows. Destinations reused across rows then never keep a value from the
+// previous row: a custom sql.Scanner that ignores NULL, or a column failing under
+// WithAllErrors, leaves the zero value behind.
+//
+//	config := sqlnull.NewConfig(sqlnull.WithResetTargets())
+//	err = config.EachCtx(ctx, rows, []any{&id, &phone}, fn)
+func WithResetTargets() Option {
+	return func(c *Config) {
+		c.resetTargets = true
+	}
+}
+
+// reset sets the values targets point to to their zero value, when WithResetTargets is set.
+func (c *Config) reset(targets ...any) {
+	if !c.resetTargets {
+		return
+	}
+	zeroTargets(targets...)
+}
+
+// zeroTargets sets the values targets point to to their zero value. The scanners of the
+// package wrapping other targets are kept intact and the targets they wrap are reset
+// instead.
+func zeroTargets(targets ...any) {
+	for _, target := range targets {
+		switch t := target.(type) {
+		case *NullValue:
+			zeroTargets(t.target)
+		case *firstOf:
+			zeroTargets(t.targets...)
+		case *tee:
+			zeroTargets(t.targets...)
+		case *StreamTarget:
+			// writes straight to its writer, keeping nothing from the previous row
+		default:
+			val := reflect.ValueOf(target)
+			if val.Kind() == reflect.Ptr && !val.IsNil() {
+				val.Elem().SetZero()
+			}
+		}
+	}
+}
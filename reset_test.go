@@ -0,0 +1,111 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+// stickyString is a scanner that ignores NULL, keeping whatever it held before.
+type stickyString string
+
+func (s *stickyString) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		*s = stickyString(v)
+	case []byte:
+		*s = stickyString(v)
+	}
+	return nil
+}
+
+func TestWithResetTargets(t *testing.T) {
+	db := makeusers(t)
+
+	each := func(config *sqlnull.Config) []stickyString {
+		rows, err := db.Query("SELECT id, last_name FROM users ORDER BY id")
+		require.NoError(t, err)
+
+		var id int64
+		var lastName stickyString
+		var got []stickyString
+		err = config.EachCtx(context.Background(), rows, []any{&id, &lastName}, func() error {
+			got = append(got, lastName)
+			return nil
+		})
+		require.NoError(t, err)
+		return got
+	}
+	require.Equal(t, []stickyString{"doe", "doe"}, each(sqlnull.NewConfig()))
+	require.Equal(t, []stickyString{"doe", ""}, each(sqlnull.NewConfig(sqlnull.WithResetTargets())))
+
+	type User struct {
+		ID       int64
+		LastName stickyString `db:"last_name"`
+		Note     string
+	}
+	rows, err := db.Query("SELECT id, last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	config := sqlnull.NewConfig(sqlnull.WithResetTargets())
+	user := User{Note: "stale"}
+	require.True(t, rows.Next())
+	require.NoError(t, config.ScanStruct(rows, &user))
+	require.Equal(t, User{ID: 1, LastName: "doe"}, user)
+	require.True(t, rows.Next())
+	require.NoError(t, config.ScanStruct(rows, &user))
+	require.Equal(t, User{ID: 2}, user)
+}
+
+func TestWithResetTargetsRows(t *testing.T) {
+	db := makeusers(t)
+	config := sqlnull.NewConfig(sqlnull.WithResetTargets())
+	rows, err := config.Query(context.Background(), db, "SELECT id, last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var id int64
+	var lastName stickyString
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&id, &lastName))
+	require.Equal(t, stickyString("doe"), lastName)
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&id, &lastName))
+	require.Equal(t, int64(2), id)
+	require.Empty(t, lastName)
+}
+
+func TestWithResetTargetsWrappers(t *testing.T) {
+	db := makeusers(t)
+	config := sqlnull.NewConfig(sqlnull.WithResetTargets())
+	const query = "SELECT last_name FROM users WHERE id=?"
+
+	var n *int64
+	var s *string
+	require.NoError(t, config.Scan(db.QueryRow(query, 1).Scan, config.FirstOf(&n, &s)))
+	require.Nil(t, n)
+	require.Equal(t, "doe", *s)
+
+	var audit stickyString
+	require.NoError(t, config.Scan(db.QueryRow(query, 1).Scan, config.Tee(&s, &audit)))
+	require.Equal(t, stickyString("doe"), audit)
+	require.NoError(t, config.Scan(db.QueryRow(query, 2).Scan, config.Tee(&s, &audit)))
+	require.Nil(t, s)
+	require.Empty(t, audit)
+
+	var buf bytes.Buffer
+	blob := sqlnull.WriteTo(&buf)
+	require.NoError(t, config.Scan(db.QueryRow(query, 1).Scan, blob))
+	require.Equal(t, "doe", buf.String())
+
+	require.NoError(t, config.Scan(db.QueryRow(query, 1).Scan, config.New(&s)))
+	require.Equal(t, "doe", *s)
+	require.NoError(t, config.Scan(db.QueryRow(query, 2).Scan, config.New(&s)))
+	require.Nil(t, s)
+}
@@ -0,0 +1,202 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// QueryError decorates an error met while reading the result of a query with the query
+// text, the row being scanned and the failing column, when known.
+type QueryError struct {
+	Query  string
+	Row    int    // 1-based number of the row being scanned, 0 when no row was reached
+	Column string // failing column, when known
+	Err    error
+}
+
+// Error implements the error interface for QueryError.
+func (e *QueryError) Error() string {
+	switch {
+	case e.Column != "":
+		return fmt.Sprintf("query %q, row %d, column %q: %v", e.Query, e.Row, e.Column, e.Err)
+	case e.Row > 0:
+		return fmt.Sprintf("query %q, row %d: %v", e.Query, e.Row, e.Err)
+	}
+	return fmt.Sprintf("query %q: %v", e.Query, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// Rows wraps *sql.Rows, remembering the query text and the columns, so any scan or
+// conversion error is returned as a *QueryError naming the query, the row number and the
+// column. Scan wraps its targets like Scanner.
+//
+//	rows, err := sqlnull.Query(ctx, db, "SELECT id, phone FROM users")
+//	for rows.Next() {
+//		err = rows.Scan(&id, &phone) // query "SELECT id, phone FROM users", row 3, column "phone": ...
+//	}
+type Rows struct {
+	*sql.Rows
+	query   string
+	columns []string
+	row     int
+	config  *Config
+}
+
+// Query runs query on db and returns its result wrapped in Rows.
+func Query(ctx context.Context, db Queryer, query string, args ...any) (*Rows, error) {
+	return ConfigFromContext(ctx).Query(ctx, db, query, args...)
+}
+
+// Query runs query on db and returns its result wrapped in Rows.
+func (c *Config) Query(ctx context.Context, db Queryer, query string, args ...any) (*Rows, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, &QueryError{Query: query, Err: err}
+	}
+	return c.WrapRows(rows, query), nil
+}
+
+// WrapRows wraps rows returned for query.
+func WrapRows(rows *sql.Rows, query string) *Rows {
+	return Default().WrapRows(rows, query)
+}
+
+// WrapRows wraps rows returned for query, scanning with c.
+func (c *Config) WrapRows(rows *sql.Rows, query string) *Rows {
+	columns, _ := rows.Columns()
+	return &Rows{Rows: rows, query: query, columns: columns, config: c}
+}
+
+// Next prepares the next row for Scan.
+func (r *Rows) Next() bool {
+	if !r.Rows.Next() {
+		return false
+	}
+	r.row++
+	return true
+}
+
+// Scan wraps targets like Scanner and scans the current row into them.
+func (r *Rows) Scan(targets ...any) error {
+	failed := -1
+	r.config.reset(targets...)
+	return r.wrap(r.config.scanRow(r.tracked(&failed), r.config.Scanner(targets...), r.columns, nil), failed)
+}
+
+// ScanStruct scans the current row into the struct pointed to by dest like ScanStruct.
+func (r *Rows) ScanStruct(dest any) error {
+	failed := -1
+	return r.wrap(r.config.scanStructRow(r.tracked(&failed), r.columns, dest, nil), failed)
+}
+
+// tracked returns a scan function for the current row recording in failed the index of
+// the first target that fails to convert.
+func (r *Rows) tracked(failed *int) func(dest ...any) error {
+	return func(dest ...any) error {
+		for i, target := range dest {
+			if s, ok := target.(sql.Scanner); ok {
+				dest[i] = scanFunc(func(src any) error {
+					err := s.Scan(src)
+					if err != nil && *failed < 0 {
+						*failed = i
+					}
+					return err
+				})
+			}
+		}
+		err := r.Rows.Scan(dest...)
+		if err == nil || *failed >= 0 || len(dest) != len(r.columns) {
+			return err
+		}
+		// a target left to database/sql failed: the row can be scanned again, one target at a time
+		for i, target := range dest {
+			if _, ok := target.(scanFunc); ok {
+				continue
+			}
+			probe := make([]any, len(dest))
+			for j := range probe {
+				probe[j] = discard
+			}
+			probe[i] = target
+			if r.Rows.Scan(probe...) != nil {
+				*failed = i
+				break
+			}
+		}
+		return err
+	}
+}
+
+// Err returns the error met during iteration, if any.
+func (r *Rows) Err() error {
+	return r.wrap(r.Rows.Err(), -1)
+}
+
+// wrap decorates err with the query, the current row and the column at index failed, or
+// the column named by a *ColumnError in err.
+func (r *Rows) wrap(err error, failed int) error {
+	if err == nil {
+		return nil
+	}
+	qe := &QueryError{Query: r.query, Row: r.row, Err: err}
+	var ce *ColumnError
+	if errors.As(err, &ce) && ce.Column != "" {
+		qe.Column = ce.Column
+	} else if failed >= 0 && failed < len(r.columns) {
+		qe.Column = r.columns[failed]
+	}
+	return qe
+}
+
+// Row is the result of QueryRow, like *sql.Row with errors returned as *QueryError.
+type Row struct {
+	rows *Rows
+	err  error
+}
+
+// QueryRow runs query on db, expecting at most one row.
+func QueryRow(ctx context.Context, db Queryer, query string, args ...any) *Row {
+	return ConfigFromContext(ctx).QueryRow(ctx, db, query, args...)
+}
+
+// QueryRow runs query on db, expecting at most one row.
+func (c *Config) QueryRow(ctx context.Context, db Queryer, query string, args ...any) *Row {
+	rows, err := c.Query(ctx, db, query, args...)
+	return &Row{rows: rows, err: err}
+}
+
+// Scan wraps targets like Scanner and scans the first row into them. It returns an error
+// wrapping sql.ErrNoRows when the query returned no rows.
+func (r *Row) Scan(targets ...any) error {
+	return r.scan(func() error { return r.rows.Scan(targets...) })
+}
+
+// ScanStruct scans the first row into the struct pointed to by dest like ScanStruct.
+func (r *Row) ScanStruct(dest any) error {
+	return r.scan(func() error { return r.rows.ScanStruct(dest) })
+}
+
+func (r *Row) scan(scan func() error) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.rows.Close()
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return r.rows.wrap(sql.ErrNoRows, -1)
+	}
+	if err := scan(); err != nil {
+		return err
+	}
+	return r.rows.wrap(r.rows.Close(), -1)
+}
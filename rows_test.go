@@ -0,0 +1,79 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRows(t *testing.T) {
+	db := makeusers(t)
+	ctx := context.Background()
+
+	const query = "SELECT id, first_name FROM users ORDER BY id"
+	rows, err := sqlnull.Query(ctx, db, query)
+	require.NoError(t, err)
+
+	require.True(t, rows.Next())
+	var id int64
+	var name *string
+	require.NoError(t, rows.Scan(&id, &name))
+	require.Equal(t, "john", *name)
+
+	require.True(t, rows.Next())
+	var n int64
+	err = rows.Scan(&id, &n)
+	var qe *sqlnull.QueryError
+	require.ErrorAs(t, err, &qe)
+	require.Equal(t, query, qe.Query)
+	require.Equal(t, 2, qe.Row)
+	require.Equal(t, "first_name", qe.Column)
+	require.ErrorContains(t, err, `query "SELECT id, first_name FROM users ORDER BY id", row 2, column "first_name": `)
+	require.NoError(t, rows.Close())
+
+	type User struct {
+		ID        int64
+		FirstName int64
+	}
+	rows, err = sqlnull.Query(ctx, db, query)
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	err = rows.ScanStruct(&User{})
+	require.ErrorAs(t, err, &qe)
+	require.Equal(t, 1, qe.Row)
+	require.Equal(t, "first_name", qe.Column)
+	require.NoError(t, rows.Close())
+
+	_, err = sqlnull.Query(ctx, db, "SELECT nope FROM users")
+	require.ErrorAs(t, err, &qe)
+	require.Equal(t, "SELECT nope FROM users", qe.Query)
+}
+
+func TestQueryRow(t *testing.T) {
+	db := makeusers(t)
+	ctx := context.Background()
+
+	var lastName *string
+	require.NoError(t, sqlnull.QueryRow(ctx, db, "SELECT last_name FROM users WHERE id=?", 2).Scan(&lastName))
+	require.Nil(t, lastName)
+
+	var user UserView
+	require.NoError(t, sqlnull.QueryRow(ctx, db, "SELECT id, first_name, last_name FROM users WHERE id=?", 1).ScanStruct(&user))
+	require.Equal(t, "john doe", user.FullName)
+
+	err := sqlnull.QueryRow(ctx, db, "SELECT last_name FROM users WHERE id=?", 3).Scan(&lastName)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.ErrorContains(t, err, `query "SELECT last_name FROM users WHERE id=?"`)
+
+	config := sqlnull.NewConfig(sqlnull.WithAllErrors())
+	var id, first int64
+	err = config.QueryRow(ctx, db, "SELECT id, first_name FROM users WHERE id=?", 1).Scan(&id, &first)
+	var qe *sqlnull.QueryError
+	require.ErrorAs(t, err, &qe)
+	require.Equal(t, "first_name", qe.Column)
+}
@@ -0,0 +1,74 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+)
+
+// Rune returns a scanner filling dst from a column holding characters, for schemas storing
+// single-character codes. dst points to a rune, or any type based on int32, to a []rune,
+// or to a pointer to either. Text fills a rune with its one and only character, so a
+// CHAR(1) column holding "7" scans as '7', while integers scan as code points. A []rune
+// receives the characters of the text. NULL stores zero, a nil slice or a nil pointer.
+// Plain int32 and []int32 targets never read text as characters, since rune is an alias
+// of int32. ScanStruct does the same for fields tagged with the rune option: `db:"grade,rune"`.
+//
+//	var grade *rune
+//	err = row.Scan(&id, sqlnull.Rune(&grade))
+func Rune(dst any) sql.Scanner {
+	return scanFunc(func(src any) error {
+		val := reflect.ValueOf(dst)
+		if val.Kind() != reflect.Ptr || val.IsNil() {
+			return fmt.Errorf("Rune destination must be a non-nil pointer, got %T", dst)
+		}
+		return scanRune(val.Elem(), src)
+	})
+}
+
+// scanRune stores the character, or characters, src into dst.
+func scanRune(dst reflect.Value, src any) error {
+	if src == nil {
+		dst.SetZero()
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr {
+		ptr := reflect.New(dst.Type().Elem())
+		if err := scanRune(ptr.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	}
+
+	s, isText := text(src)
+	switch {
+	case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Int32:
+		if !isText {
+			return fmt.Errorf("cannot scan %T value into %s", src, dst.Type())
+		}
+		dst.Set(reflect.ValueOf([]rune(s)).Convert(dst.Type()))
+	case dst.Kind() == reflect.Int32:
+		if v, ok := src.(int64); ok {
+			if v < 0 || v > utf8.MaxRune {
+				return fmt.Errorf("%d is not a valid code point for %s", v, dst.Type())
+			}
+			dst.SetInt(v)
+			return nil
+		}
+		if !isText {
+			return fmt.Errorf("cannot scan %T value into %s", src, dst.Type())
+		}
+		r, size := utf8.DecodeRuneInString(s)
+		if size == 0 || size != len(s) || (r == utf8.RuneError && size == 1) {
+			return fmt.Errorf("cannot scan %q into %s: not a single character", s, dst.Type())
+		}
+		dst.SetInt(int64(r))
+	default:
+		return fmt.Errorf("cannot scan a character into %s", dst.Type())
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type Grade rune
+type Word []rune
+
+func TestRune(t *testing.T) {
+	var r *rune
+	require.NoError(t, sqlnull.Rune(&r).Scan("é"))
+	require.Equal(t, 'é', *r)
+
+	require.NoError(t, sqlnull.Rune(&r).Scan([]byte("A")))
+	require.Equal(t, 'A', *r)
+
+	require.NoError(t, sqlnull.Rune(&r).Scan(int64(66)))
+	require.Equal(t, 'B', *r)
+
+	require.NoError(t, sqlnull.Rune(&r).Scan(nil))
+	require.Nil(t, r)
+
+	require.NoError(t, sqlnull.Rune(&r).Scan("7"))
+	require.Equal(t, '7', *r)
+
+	require.Error(t, sqlnull.Rune(&r).Scan("AB"))
+	require.Error(t, sqlnull.Rune(&r).Scan("42"))
+	require.Error(t, sqlnull.Rune(&r).Scan(""))
+	require.Error(t, sqlnull.Rune(&r).Scan(int64(-1)))
+
+	var g Grade
+	require.NoError(t, sqlnull.Rune(&g).Scan("C"))
+	require.Equal(t, Grade('C'), g)
+
+	var s string
+	require.Error(t, sqlnull.Rune(&s).Scan("C"))
+
+	type Student struct {
+		Grade *Grade `db:"grade,rune"`
+	}
+	var student Student
+	require.NoError(t, sqlnull.FieldTarget(&student.Grade, "grade,rune").(sql.Scanner).Scan("D"))
+	require.Equal(t, Grade('D'), *student.Grade)
+}
+
+func TestInt32NotRune(t *testing.T) {
+	var n *int32
+	require.NoError(t, sqlnull.New(&n).Scan("7"))
+	require.Equal(t, int32(7), *n)
+
+	require.Error(t, sqlnull.New(&n).Scan("A"))
+
+	var g *Grade
+	require.Error(t, sqlnull.New(&g).Scan("C"))
+}
+
+func TestRunes(t *testing.T) {
+	var runes *[]rune
+	require.NoError(t, sqlnull.Rune(&runes).Scan("héllo"))
+	require.Equal(t, []rune("héllo"), *runes)
+
+	require.NoError(t, sqlnull.Rune(&runes).Scan(nil))
+	require.Nil(t, runes)
+
+	var w Word
+	require.NoError(t, sqlnull.Rune(&w).Scan([]byte("wörld")))
+	require.Equal(t, Word("wörld"), w)
+
+	require.Error(t, sqlnull.Rune(&w).Scan(int64(1)))
+}
+
+func TestInt32SliceNotRunes(t *testing.T) {
+	var ids *[]int32
+	require.NoError(t, sqlnull.Target(&ids).(sql.Scanner).Scan("{1,2,3}"))
+	require.Equal(t, []int32{1, 2, 3}, *ids)
+}
@@ -0,0 +1,67 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// ScanAll reads the remaining rows of rows and appends them to the slice dest points to.
+// A struct T, or pointer to struct, is filled like ScanStruct does; any other T, such as
+// *string or time.Time, receives the single column of the result set. rows is always
+// closed on return.
+//
+//	rows, err := db.Query("SELECT id, first_name, last_name FROM users")
+//	var users []User
+//	err = sqlnull.ScanAll(rows, &users)
+func ScanAll[T any](rows *sql.Rows, dest *[]T) error {
+	defer rows.Close()
+	c := Default()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	valueType := reflect.TypeOf(dest).Elem().Elem()
+	isStruct := isRowStruct(valueType)
+	if !isStruct && len(columns) != 1 {
+		return fmt.Errorf("ScanAll: %s values need exactly one column, got %d columns", valueType, len(columns))
+	}
+
+	for rows.Next() {
+		var value T
+		if isStruct {
+			target := reflect.ValueOf(&value)
+			if valueType.Kind() == reflect.Ptr {
+				target.Elem().Set(reflect.New(valueType.Elem()))
+				target = target.Elem()
+			}
+			if err := c.ScanStruct(rows, target.Interface()); err != nil {
+				return err
+			}
+		} else if err := c.scanRow(rows.Scan, []any{c.fieldTarget(&value)}, columns, nil); err != nil {
+			return err
+		}
+		*dest = append(*dest, value)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// isRowStruct reports whether t, a struct or pointer to struct, receives a whole row
+// rather than being a single column value such as time.Time, a sql.Scanner, a Setter or a
+// type registered with Register.
+func isRowStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if _, ok := lookupConverter(t); ok {
+		return false
+	}
+	return t.Kind() == reflect.Struct && t != timeType && t != ratType &&
+		!reflect.PointerTo(t).Implements(scannerType) && !reflect.PointerTo(t).Implements(setterType)
+}
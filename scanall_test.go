@@ -0,0 +1,53 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanAll(t *testing.T) {
+	db := makeusers(t)
+
+	rows, err := db.Query("SELECT id, first_name, last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	var users []UserView
+	require.NoError(t, sqlnull.ScanAll(rows, &users))
+	require.Len(t, users, 2)
+	require.Equal(t, "john doe", users[0].FullName)
+	require.Nil(t, users[1].LastName)
+
+	rows, err = db.Query("SELECT id, first_name, last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	ptrs := []*UserView{{ID: 9}}
+	require.NoError(t, sqlnull.ScanAll(rows, &ptrs))
+	require.Len(t, ptrs, 3)
+	require.Equal(t, "jane", ptrs[2].FullName)
+
+	rows, err = db.Query("SELECT last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	var lastNames []*string
+	require.NoError(t, sqlnull.ScanAll(rows, &lastNames))
+	require.Equal(t, []*string{sqlnull.Ptr("doe"), nil}, lastNames)
+
+	rows, err = db.Query("SELECT last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	var nulls []sqlnull.Null[string]
+	require.NoError(t, sqlnull.ScanAll(rows, &nulls))
+	require.Equal(t, []sqlnull.Null[string]{{V: "doe", Valid: true}, {}}, nulls)
+
+	rows, err = db.Query("SELECT verified_at FROM users ORDER BY id")
+	require.NoError(t, err)
+	var times []time.Time
+	require.NoError(t, sqlnull.ScanAll(rows, &times))
+	require.Equal(t, []time.Time{{}, {}}, times)
+
+	rows, err = db.Query("SELECT id, last_name FROM users")
+	require.NoError(t, err)
+	var ids []int64
+	require.ErrorContains(t, sqlnull.ScanAll(rows, &ids), "exactly one column")
+}
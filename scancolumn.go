@@ -0,0 +1,59 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// ScanColumn reads the single column of the remaining rows of rows into a slice, for
+// queries such as SELECT id FROM users. What becomes of NULL depends on T: pointers hold
+// nil, sql.Null* and other wrappers with a Valid flag are left invalid, and rows holding
+// NULL are skipped for any other T, which has no way to tell NULL from a zero value.
+// rows is always closed on return.
+//
+//	rows, err := db.Query("SELECT phone FROM users")
+//	phones, err := sqlnull.ScanColumn[string](rows) // users without a phone are left out
+func ScanColumn[T any](rows *sql.Rows) ([]T, error) {
+	defer rows.Close()
+	c := Default()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) != 1 {
+		return nil, fmt.Errorf("ScanColumn: need exactly one column, got %d columns", len(columns))
+	}
+
+	var zero T
+	valueType := reflect.TypeOf(&zero).Elem()
+	nullable := valueType.Kind() == reflect.Ptr || isNullWrapper(valueType) || valueType.Implements(optionalType)
+
+	result := []T{}
+	for rows.Next() {
+		var value T
+		target := c.fieldTarget(&value)
+		null := false
+		record := scanFunc(func(src any) error {
+			null = src == nil
+			if s, ok := target.(sql.Scanner); ok {
+				return s.Scan(src)
+			}
+			return c.scan(target, src)
+		})
+		if err := c.scanRow(rows.Scan, []any{record}, columns, nil); err != nil {
+			return nil, err
+		}
+		if null && !nullable {
+			continue
+		}
+		result = append(result, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, rows.Close()
+}
@@ -0,0 +1,53 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanColumn(t *testing.T) {
+	db := makeusers(t)
+	const query = "SELECT last_name FROM users ORDER BY id"
+
+	rows, err := db.Query(query)
+	require.NoError(t, err)
+	names, err := sqlnull.ScanColumn[string](rows)
+	require.NoError(t, err)
+	require.Equal(t, []string{"doe"}, names)
+
+	rows, err = db.Query(query)
+	require.NoError(t, err)
+	ptrs, err := sqlnull.ScanColumn[*string](rows)
+	require.NoError(t, err)
+	require.Len(t, ptrs, 2)
+	require.Equal(t, "doe", *ptrs[0])
+	require.Nil(t, ptrs[1])
+
+	rows, err = db.Query(query)
+	require.NoError(t, err)
+	wrapped, err := sqlnull.ScanColumn[sql.NullString](rows)
+	require.NoError(t, err)
+	require.Equal(t, []sql.NullString{{String: "doe", Valid: true}, {}}, wrapped)
+
+	rows, err = db.Query("SELECT id FROM users WHERE id > 5")
+	require.NoError(t, err)
+	ids, err := sqlnull.ScanColumn[int64](rows)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+	require.NotNil(t, ids)
+
+	rows, err = db.Query("SELECT id, first_name FROM users")
+	require.NoError(t, err)
+	_, err = sqlnull.ScanColumn[int64](rows)
+	require.ErrorContains(t, err, "need exactly one column, got 2 columns")
+
+	rows, err = db.Query("SELECT first_name FROM users")
+	require.NoError(t, err)
+	_, err = sqlnull.ScanColumn[int64](rows)
+	require.Error(t, err)
+}
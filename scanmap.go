@@ -0,0 +1,109 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ScanAllMap reads the remaining rows of rows and indexes them by the value of keyColumn.
+// A struct T, or pointer to struct, is filled like ScanStruct does, the key column included
+// when it has a matching field and skipped otherwise. Any other T receives the single column
+// besides the key:
+//
+//	rows, err := db.Query("SELECT id, nickname FROM users")
+//	nicknames, err := sqlnull.ScanAllMap[int64, *string](rows, "id")
+//
+// The key is converted like a scan target. A NULL key fails unless K is a pointer or
+// Null type, and a later row replaces an earlier one with the same key. rows is always
+// closed on return.
+func ScanAllMap[K comparable, T any](rows *sql.Rows, keyColumn string) (map[K]T, error) {
+	defer rows.Close()
+	c := Default()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	keyIndex := -1
+	for i, column := range columns {
+		if column == keyColumn {
+			keyIndex = i
+			break
+		}
+	}
+	if keyIndex < 0 {
+		return nil, fmt.Errorf("ScanAllMap: key column %q not in result set", keyColumn)
+	}
+
+	keyType := reflect.TypeOf((*K)(nil)).Elem()
+	nullable := keyType.Kind() == reflect.Ptr || isNullWrapper(keyType) || keyType.Implements(optionalType)
+
+	var zero T
+	valueType := reflect.TypeOf(&zero).Elem()
+	isStruct := isRowStruct(valueType)
+	if !isStruct && len(columns) != 2 {
+		return nil, fmt.Errorf("ScanAllMap: %s values need exactly one column besides the key, got %d columns", valueType, len(columns))
+	}
+
+	// a struct without a field for the key column does not receive it
+	structColumns, structScan := columns, rows.Scan
+	if isStruct {
+		structType := valueType
+		if structType.Kind() == reflect.Ptr {
+			structType = structType.Elem()
+		}
+		if !slices.ContainsFunc(structFields(structType), func(field structField) bool { return field.match(keyColumn) }) {
+			structColumns = slices.Delete(slices.Clone(columns), keyIndex, keyIndex+1)
+			structScan = func(dest ...any) error {
+				return rows.Scan(slices.Insert(dest, keyIndex, any(discard))...)
+			}
+		}
+	}
+
+	result := make(map[K]T)
+	for rows.Next() {
+		var value T
+		if isStruct {
+			dest := reflect.ValueOf(&value).Elem()
+			if valueType.Kind() == reflect.Ptr {
+				dest.Set(reflect.New(valueType.Elem()))
+			} else {
+				dest = dest.Addr()
+			}
+			if err := c.scanStructRow(structScan, structColumns, dest.Interface(), nil); err != nil {
+				return nil, err
+			}
+		} else {
+			targets := make([]any, 2)
+			targets[keyIndex], targets[1-keyIndex] = discard, c.fieldTarget(&value)
+			if err := c.scanRow(rows.Scan, targets, columns, nil); err != nil {
+				return nil, err
+			}
+		}
+
+		// a new key for every row, so pointer keys do not share the value they point to
+		var key K
+		keyTargets := make([]any, len(columns))
+		for i := range keyTargets {
+			keyTargets[i] = discard
+		}
+		keyTargets[keyIndex] = scanFunc(func(src any) error {
+			if src == nil && !nullable {
+				return fmt.Errorf("NULL in key column %q", keyColumn)
+			}
+			return c.scan(&key, src)
+		})
+		if err := rows.Scan(keyTargets...); err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, rows.Close()
+}
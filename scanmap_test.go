@@ -0,0 +1,76 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanAllMap(t *testing.T) {
+	db := makeusers(t)
+
+	rows, err := db.Query("SELECT id, first_name, last_name, verified_at FROM users")
+	require.NoError(t, err)
+	users, err := sqlnull.ScanAllMap[int64, *UserView](rows, "id")
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, int64(1), users[1].ID)
+	require.Equal(t, "john doe", users[1].FullName)
+	require.Nil(t, users[2].LastName)
+
+	rows, err = db.Query("SELECT first_name, last_name FROM users")
+	require.NoError(t, err)
+	lastNames, err := sqlnull.ScanAllMap[string, *string](rows, "first_name")
+	require.NoError(t, err)
+	require.Equal(t, "doe", *lastNames["john"])
+	require.Contains(t, lastNames, "jane")
+	require.Nil(t, lastNames["jane"])
+
+	rows, err = db.Query("SELECT last_name, id FROM users")
+	require.NoError(t, err)
+	_, err = sqlnull.ScanAllMap[string, int64](rows, "last_name")
+	require.ErrorContains(t, err, `NULL in key column "last_name"`)
+
+	rows, err = db.Query("SELECT last_name, id FROM users")
+	require.NoError(t, err)
+	ids, err := sqlnull.ScanAllMap[sql.NullString, int64](rows, "last_name")
+	require.NoError(t, err)
+	require.Equal(t, map[sql.NullString]int64{{String: "doe", Valid: true}: 1, {}: 2}, ids)
+
+	rows, err = db.Query("SELECT id FROM users")
+	require.NoError(t, err)
+	_, err = sqlnull.ScanAllMap[int64, UserView](rows, "missing")
+	require.Error(t, err)
+}
+
+func TestScanAllMapKeyColumn(t *testing.T) {
+	db := makeusers(t)
+
+	type Name struct {
+		FirstName string
+		LastName  *string
+	}
+	rows, err := db.Query("SELECT first_name, id, last_name FROM users")
+	require.NoError(t, err)
+	names, err := sqlnull.ScanAllMap[int64, Name](rows, "id")
+	require.NoError(t, err)
+	require.Equal(t, "john", names[1].FirstName)
+	require.Equal(t, "doe", *names[1].LastName)
+	require.Equal(t, "jane", names[2].FirstName)
+	require.Nil(t, names[2].LastName)
+
+	rows, err = db.Query("SELECT id, first_name FROM users")
+	require.NoError(t, err)
+	firstNames, err := sqlnull.ScanAllMap[*int64, string](rows, "id")
+	require.NoError(t, err)
+	require.Len(t, firstNames, 2)
+	byID := make(map[int64]string)
+	for id, name := range firstNames {
+		byID[*id] = name
+	}
+	require.Equal(t, map[int64]string{1: "john", 2: "jane"}, byID)
+}
@@ -0,0 +1,30 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import "fmt"
+
+// discard is a scan target that ignores its column.
+var discard = scanFunc(func(src any) error { return nil })
+
+// ScannerN wraps targets like Scanner and pads them with discarding targets up to n, the
+// number of columns in the result set, so trailing columns that are not needed can be
+// skipped without counting them by hand. It fails when there are more targets than columns.
+//
+//	columns, _ := rows.Columns()
+//	targets, err := sqlnull.ScannerN(len(columns), &id, &username)
+func ScannerN(n int, targets ...any) ([]any, error) {
+	return Default().ScannerN(n, targets...)
+}
+
+// ScannerN wraps targets like Scanner and pads them with discarding targets up to n.
+func (c *Config) ScannerN(n int, targets ...any) ([]any, error) {
+	if len(targets) > n {
+		return nil, fmt.Errorf("got %d targets for %d columns", len(targets), n)
+	}
+	result := c.Scanner(targets...)
+	for len(result) < n {
+		result = append(result, discard)
+	}
+	return result, nil
+}
@@ -0,0 +1,26 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScannerN(t *testing.T) {
+	db := makeusers(t)
+
+	var id int64
+	var firstName string
+	targets, err := sqlnull.ScannerN(4, &id, &firstName)
+	require.NoError(t, err)
+	require.Len(t, targets, 4)
+	require.NoError(t, db.QueryRow("SELECT * FROM users WHERE id = 2").Scan(targets...))
+	require.Equal(t, int64(2), id)
+	require.Equal(t, "jane", firstName)
+
+	_, err = sqlnull.ScannerN(1, &id, &firstName)
+	require.ErrorContains(t, err, "got 2 targets for 1 columns")
+}
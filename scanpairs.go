@@ -0,0 +1,55 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// ScanPairs reads the remaining rows of a two-column result set into a map from the first
+// column to the second, for key/value queries:
+//
+//	rows, err := db.Query("SELECT name, value FROM settings")
+//	settings, err := sqlnull.ScanPairs[string, *string](rows)
+//
+// Both columns are converted like scan targets, so a NULL value is stored as nil for a
+// pointer V and as the zero value otherwise. A NULL key fails unless K is a pointer or
+// Null type, and a later row replaces an earlier one with the same key. rows is always
+// closed on return.
+func ScanPairs[K comparable, V any](rows *sql.Rows) (map[K]V, error) {
+	defer rows.Close()
+	c := Default()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) != 2 {
+		return nil, fmt.Errorf("ScanPairs: need exactly two columns, got %d columns", len(columns))
+	}
+
+	keyType := reflect.TypeOf((*K)(nil)).Elem()
+	nullable := keyType.Kind() == reflect.Ptr || isNullWrapper(keyType) || keyType.Implements(optionalType)
+
+	result := make(map[K]V)
+	for rows.Next() {
+		var key K
+		var value V
+		keyTarget := scanFunc(func(src any) error {
+			if src == nil && !nullable {
+				return fmt.Errorf("NULL in key column %q", columns[0])
+			}
+			return c.scan(&key, src)
+		})
+		if err := c.scanRow(rows.Scan, []any{keyTarget, c.fieldTarget(&value)}, columns, nil); err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, rows.Close()
+}
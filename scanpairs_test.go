@@ -0,0 +1,44 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanPairs(t *testing.T) {
+	db := makeusers(t)
+
+	rows, err := db.Query("SELECT id, last_name FROM users")
+	require.NoError(t, err)
+	names, err := sqlnull.ScanPairs[int64, *string](rows)
+	require.NoError(t, err)
+	require.Len(t, names, 2)
+	require.Equal(t, "doe", *names[1])
+	require.Nil(t, names[2])
+
+	rows, err = db.Query("SELECT first_name, last_name FROM users")
+	require.NoError(t, err)
+	plain, err := sqlnull.ScanPairs[string, string](rows)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"john": "doe", "jane": ""}, plain)
+
+	rows, err = db.Query("SELECT last_name, id FROM users")
+	require.NoError(t, err)
+	_, err = sqlnull.ScanPairs[string, int64](rows)
+	require.ErrorContains(t, err, `NULL in key column "last_name"`)
+
+	rows, err = db.Query("SELECT last_name, id FROM users")
+	require.NoError(t, err)
+	byName, err := sqlnull.ScanPairs[*string, int64](rows)
+	require.NoError(t, err)
+	require.Len(t, byName, 2)
+
+	rows, err = db.Query("SELECT id FROM users")
+	require.NoError(t, err)
+	_, err = sqlnull.ScanPairs[int64, int64](rows)
+	require.ErrorContains(t, err, "need exactly two columns, got 1 columns")
+}
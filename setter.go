@@ -0,0 +1,85 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+)
+
+// Setter is implemented by nullable types that receive scanned values without
+// reflection or registration. Target wraps a target implementing Setter, preferring it
+// to sql.Scanner: NULL calls SetNull, any other value calls SetValue with the driver
+// value, []byte copied, after the byte limit and time options of the Config are applied.
+// Pointers to a Setter type, such as **Phone, receive values the same way and are left
+// nil on NULL.
+//
+//	func (p *Phone) SetNull() { p.Number, p.Valid = "", false }
+//
+//	func (p *Phone) SetValue(v any) error {
+//		s, ok := v.(string)
+//		if !ok {
+//			return fmt.Errorf("phone: unexpected %T", v)
+//		}
+//		p.Number, p.Valid = s, true
+//		return nil
+//	}
+type Setter interface {
+	SetNull()
+	SetValue(v any) error
+}
+
+// setterTarget returns a scanner for target when it implements Setter.
+func (c *Config) setterTarget(target any) sql.Scanner {
+	setter, ok := target.(Setter)
+	if !ok {
+		return nil
+	}
+	return scanFunc(func(src any) error {
+		if src == nil {
+			setter.SetNull()
+			return nil
+		}
+		src, _, err := c.limit(src)
+		if err != nil {
+			return err
+		}
+		if b, ok := src.([]byte); ok {
+			src = bytes.Clone(b)
+		}
+		return setter.SetValue(c.finish(src))
+	})
+}
+
+var setterType = reflect.TypeOf((*Setter)(nil)).Elem()
+
+// nullSetter scans pointer targets to types implementing Setter: NULL leaves the pointer
+// nil, any other value is passed to the SetValue method of a newly allocated element.
+type nullSetter struct {
+	typ   reflect.Type
+	value any
+}
+
+// Scan implements the sql.Scanner interface for nullSetter.
+func (n *nullSetter) Scan(src any) error {
+	if src == nil {
+		n.value = nil
+		return nil
+	}
+	if b, ok := src.([]byte); ok {
+		src = bytes.Clone(b)
+	}
+	ptr := reflect.New(n.typ)
+	if err := ptr.Interface().(Setter).SetValue(src); err != nil {
+		return err
+	}
+	n.value = ptr.Elem().Interface()
+	return nil
+}
+
+// Value implements the driver.Valuer interface for nullSetter.
+func (n *nullSetter) Value() (driver.Value, error) {
+	return n.value, nil
+}
@@ -0,0 +1,120 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type Phone struct {
+	Number string
+	Valid  bool
+}
+
+func (p *Phone) SetNull() { p.Number, p.Valid = "", false }
+
+func (p *Phone) SetValue(v any) error {
+	switch v := v.(type) {
+	case string:
+		p.Number = v
+	case []byte:
+		p.Number = string(v)
+	default:
+		return errors.New("phone: unexpected value")
+	}
+	p.Valid = true
+	return nil
+}
+
+func TestSetter(t *testing.T) {
+	db := makeusers(t)
+
+	phone := Phone{Number: "stale", Valid: true}
+	require.NoError(t, db.QueryRow("SELECT last_name FROM users WHERE id=2").Scan(sqlnull.Target(&phone)))
+	require.Equal(t, Phone{}, phone)
+
+	require.NoError(t, db.QueryRow("SELECT '555-0100'").Scan(sqlnull.Target(&phone)))
+	require.Equal(t, Phone{Number: "555-0100", Valid: true}, phone)
+
+	require.EqualError(t, sqlnull.Target(&phone).(sql.Scanner).Scan(int64(1)), "phone: unexpected value")
+
+	limited := sqlnull.NewConfig(sqlnull.WithMaxBytes(3))
+	require.Error(t, limited.Target(&phone).(sql.Scanner).Scan("555-0100"))
+
+	type Contact struct {
+		ID    int64 `db:"id"`
+		Phone Phone `db:"last_name"`
+	}
+	rows, err := db.Query("SELECT id, last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+	var contacts []Contact
+	for rows.Next() {
+		var contact Contact
+		require.NoError(t, sqlnull.ScanStruct(rows, &contact))
+		contacts = append(contacts, contact)
+	}
+	require.Equal(t, []Contact{{1, Phone{"doe", true}}, {2, Phone{}}}, contacts)
+}
+
+func TestSetterPointer(t *testing.T) {
+	db := makeusers(t)
+
+	rows, err := db.Query("SELECT last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	phones, err := sqlnull.ScanColumn[*Phone](rows)
+	require.NoError(t, err)
+	require.Equal(t, []*Phone{{"doe", true}, nil}, phones)
+
+	rows, err = db.Query("SELECT last_name FROM users ORDER BY id")
+	require.NoError(t, err)
+	var all []Phone
+	require.NoError(t, sqlnull.ScanAll(rows, &all))
+	require.Equal(t, []Phone{{"doe", true}, {}}, all)
+}
+
+type Stamp struct {
+	At    time.Time
+	Valid bool
+}
+
+func (s *Stamp) SetNull() { *s = Stamp{} }
+
+func (s *Stamp) SetValue(v any) error {
+	at, ok := v.(time.Time)
+	if !ok {
+		return errors.New("stamp: unexpected value")
+	}
+	*s = Stamp{At: at, Valid: true}
+	return nil
+}
+
+func TestSetterOptions(t *testing.T) {
+	db := makeusers(t)
+	config := sqlnull.NewConfig(sqlnull.WithUTC(), sqlnull.WithMaxBytes(3))
+	src := time.Date(2024, 5, 1, 17, 30, 0, 0, time.FixedZone("UTC+7", 7*60*60))
+
+	var stamp Stamp
+	require.NoError(t, config.Target(&stamp).(sql.Scanner).Scan(src))
+	require.Equal(t, time.UTC, stamp.At.Location())
+
+	var ptr *Stamp
+	require.NoError(t, config.New(&ptr).Scan(src))
+	require.Equal(t, time.UTC, ptr.At.Location())
+
+	var phone *Phone
+	require.Error(t, config.New(&phone).Scan("555-0100"))
+
+	targets, err := sqlnull.ScannerE(&stamp, &phone)
+	require.NoError(t, err)
+	require.Implements(t, (*sql.Scanner)(nil), targets[0])
+	require.NoError(t, db.QueryRow("SELECT NULL, '555-0100'").Scan(targets...))
+	require.False(t, stamp.Valid)
+	require.Equal(t, &Phone{"555-0100", true}, phone)
+}
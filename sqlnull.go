@@ -1,3 +1,5 @@
+//go:build !sqlnull_lite
+
 // A Go package that provides a convenient way to handle SQL null values for various data types.
 // This package simplifies the process of scanning SQL results into Go structs by wrapping your target variables and providing custom SQL scanners.
 //
@@ -66,25 +68,47 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 )
 
 // NullValue wraps a target variable to handle SQL null values.
+//
+// A NullValue never writes to its own fields; every Scan only touches the
+// wrapped target, so the config it was built from may be shared freely.
 type NullValue struct {
-	target any
+	target     any
+	config     *Config
+	targetType reflect.Type
+	newNull    func() sql.Scanner // creates the converter for targetType, nil when unsupported
 }
 
 // Scan implements the sql.Scanner interface for NullValue.
 func (v *NullValue) Scan(src any) error {
-	// Validate the target and create a sql.Scanner.
-	null, targetType, err := validate(v.target)
+	config := v.config
+	if config == nil {
+		config = Default()
+	}
+
+	// Create the sql.Scanner picked for the target type.
+	newNull, targetType := v.newNull, v.targetType
+	if newNull == nil {
+		var err error
+		targetType = reflect.TypeOf(v.target)
+		if newNull, err = config.converter(targetType); err != nil {
+			return err
+		}
+	}
+	null := newNull()
+
+	src, err := config.prepare(src, targetType.Elem().Elem())
 	if err != nil {
 		return err
 	}
 
 	// Use the sql.Scanner to scan the source value.
 	if err := null.Scan(src); err != nil {
-		return err
+		return config.wrapError(err, src, targetType.Elem())
 	}
 
 	val := reflect.ValueOf(v.target).Elem()
@@ -95,7 +119,12 @@ func (v *NullValue) Scan(src any) error {
 		val.Set(reflect.Zero(targetType.Elem()))
 	} else {
 		// Convert the value to the target type.
-		newval := reflect.ValueOf(v)
+		newval := reflect.ValueOf(config.finish(v))
+		if config.strictRanges {
+			if err := checkRange(newval, targetType.Elem().Elem()); err != nil {
+				return config.wrapError(err, src, targetType.Elem())
+			}
+		}
 		if !val.Elem().CanAddr() {
 			val.Set(reflect.New(targetType.Elem().Elem()))
 		}
@@ -107,57 +136,115 @@ func (v *NullValue) Scan(src any) error {
 
 // Target returns a NullValue wrapper if the target is valid, otherwise returns the target itself.
 func Target(target any) any {
-	if target == nil {
-		return new(any)
-	}
-	if _, _, err := validate(target); err == nil {
-		return New(target)
-	}
-	return target
+	return Default().Target(target)
 }
 
 // Scanner wraps multiple targets with NullValue.
 func Scanner(targets ...any) []any {
-	var result []any
-
-	for _, target := range targets {
-		result = append(result, Target(target))
-	}
-
-	return result
+	return Default().Scanner(targets...)
 }
 
 // New creates a new NullValue for a given target.
 func New(target any) *NullValue {
-	return &NullValue{
-		target: target,
-	}
+	return Default().New(target)
 }
 
+var timeType = reflect.TypeOf(time.Time{})
+
 // validate checks if the target type is supported and returns the corresponding sql.Scanner.
 func validate(target any) (sql.Scanner, reflect.Type, error) {
 	targetType := reflect.TypeOf(target)
+	newNull, err := converter(targetType)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newNull(), targetType, nil
+}
+
+// converterCache holds the result of newConverter per target type, so scanning millions
+// of rows does not pick the converter again for every column of every row.
+var converterCache sync.Map // map[reflect.Type]converterEntry
+
+type converterEntry struct {
+	newNull func() sql.Scanner
+	err     error
+}
+
+// converter returns a function creating the sql.Scanner that converts into targets of
+// type targetType.
+func converter(targetType reflect.Type) (func() sql.Scanner, error) {
+	if entry, ok := converterCache.Load(targetType); ok {
+		return entry.(converterEntry).newNull, entry.(converterEntry).err
+	}
+	newNull, err := newConverter(targetType)
+	converterCache.Store(targetType, converterEntry{newNull: newNull, err: err})
+	return newNull, err
+}
+
+// newConverter picks the sql.Scanner converting into targets of type targetType.
+func newConverter(targetType reflect.Type) (func() sql.Scanner, error) {
 	if targetType.Kind() == reflect.Ptr && targetType.Elem().Kind() == reflect.Ptr {
-		switch targetType.Elem().Elem().Kind() {
-		case reflect.Bool:
-			return &sql.NullBool{}, targetType, nil
-		case reflect.Uint8:
-			return &sql.NullByte{}, targetType, nil
-		case reflect.Int8, reflect.Int16, reflect.Uint16:
-			return &sql.NullInt16{}, targetType, nil
-		case reflect.Int32, reflect.Uint32:
-			return &sql.NullInt32{}, targetType, nil
-		case reflect.Int64, reflect.Uint64, reflect.Int, reflect.Uint:
-			return &sql.NullInt64{}, targetType, nil
-		case reflect.String:
-			return &sql.NullString{}, targetType, nil
-		case reflect.Float32, reflect.Float64:
-			return &sql.NullFloat64{}, targetType, nil
-		case reflect.Struct:
-			if targetType.Elem().Elem() == reflect.TypeOf(time.Time{}) {
-				return &sql.NullTime{}, targetType, nil
-			}
+		elemType := targetType.Elem().Elem()
+		if fn, ok := lookupConverter(elemType); ok {
+			return func() sql.Scanner { return &nullConverted{typ: elemType, fn: fn} }, nil
+		}
+		if reflect.PointerTo(elemType).Implements(setterType) {
+			return func() sql.Scanner { return &nullSetter{typ: elemType} }, nil
+		}
+		if reflect.PointerTo(elemType).Implements(scannerType) {
+			return func() sql.Scanner { return &nullScanner{typ: elemType} }, nil
+		}
+		if isByteArray(elemType) && !reflect.PointerTo(elemType).Implements(scannerType) {
+			return func() sql.Scanner { return &nullByteArray{typ: elemType} }, nil
+		}
+		if elemType != timeType && reflect.PointerTo(elemType).Implements(binaryUnmarshalerType) {
+			return func() sql.Scanner { return &nullBinary{typ: elemType} }, nil
+		}
+		if elemType != timeType && elemType != ratType && reflect.PointerTo(elemType).Implements(textUnmarshalerType) {
+			kind := kindConverter(elemType)
+			return func() sql.Scanner { return &nullText{typ: elemType, kind: kind} }, nil
+		}
+		if elemType == durationType {
+			return func() sql.Scanner { return &nullDuration{} }, nil
+		}
+		if newNull := kindConverter(elemType); newNull != nil {
+			return newNull, nil
+		}
+	}
+	return nil, fmt.Errorf("NullValue for %s type is not supported", targetType)
+}
+
+// kindConverter picks the sql.Scanner converting into values of type elemType from its
+// kind, or returns nil when the kind has no conversion.
+func kindConverter(elemType reflect.Type) func() sql.Scanner {
+	switch elemType.Kind() {
+	case reflect.Bool:
+		return func() sql.Scanner { return &sql.NullBool{} }
+	case reflect.Uint8:
+		return func() sql.Scanner { return &sql.NullByte{} }
+	case reflect.Int8, reflect.Int16, reflect.Uint16:
+		return func() sql.Scanner { return &sql.NullInt16{} }
+	case reflect.Int32, reflect.Uint32:
+		return func() sql.Scanner { return &sql.NullInt32{} }
+	case reflect.Int64, reflect.Int:
+		return func() sql.Scanner { return &sql.NullInt64{} }
+	case reflect.Uint64, reflect.Uint:
+		return func() sql.Scanner { return &nullUint64{} }
+	case reflect.String:
+		return func() sql.Scanner { return &sql.NullString{} }
+	case reflect.Float32, reflect.Float64:
+		return func() sql.Scanner { return &sql.NullFloat64{} }
+	case reflect.Slice:
+		if elemType.Elem().Kind() == reflect.Uint8 && !reflect.PointerTo(elemType).Implements(scannerType) {
+			return func() sql.Scanner { return &nullBytes{} }
+		}
+	case reflect.Struct:
+		if elemType == timeType {
+			return func() sql.Scanner { return &sql.NullTime{} }
+		}
+		if elemType == ratType {
+			return func() sql.Scanner { return &nullRat{} }
 		}
 	}
-	return nil, nil, fmt.Errorf("NullValue for %T type is not supported", target)
+	return nil
 }
@@ -1,3 +1,5 @@
+//go:build !sqlnull_lite
+
 package sqlnull_test
 
 import (
@@ -371,3 +373,23 @@ func TestUnsupportedType(t *testing.T) {
 	err := null.Scan(99)
 	require.Error(t, err)
 }
+
+func TestNullValueReuse(t *testing.T) {
+	var n *int64
+	var s *string
+	nv, sv := sqlnull.New(&n), sqlnull.New(&s)
+	for i := range 3 {
+		require.NoError(t, nv.Scan(int64(i)))
+		require.NoError(t, sv.Scan("x"))
+		require.Equal(t, int64(i), *n)
+		require.Equal(t, "x", *s)
+
+		require.NoError(t, nv.Scan(nil))
+		require.NoError(t, sv.Scan(nil))
+		require.Nil(t, n)
+		require.Nil(t, s)
+	}
+
+	var unsupported *struct{ A int }
+	require.ErrorContains(t, sqlnull.New(&unsupported).Scan(int64(1)), "is not supported")
+}
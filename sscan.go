@@ -0,0 +1,52 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+// WithSscanFallback makes targets of types no converter handles scan through fmt.Sscan
+// of the column's text form, so one-off types with a text representation, such as
+// complex numbers or types implementing fmt.Scanner, work without a registered converter.
+func WithSscanFallback() Option {
+	return func(c *Config) {
+		c.sscanFallback = true
+	}
+}
+
+// nullSscan scans the text form of a column with fmt.Sscan.
+type nullSscan struct {
+	typ   reflect.Type
+	value any
+}
+
+// Scan implements the sql.Scanner interface for nullSscan.
+func (n *nullSscan) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case nil:
+		n.value = nil
+		return nil
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		text = fmt.Sprint(v)
+	}
+
+	ptr := reflect.New(n.typ)
+	if _, err := fmt.Sscan(text, ptr.Interface()); err != nil {
+		return fmt.Errorf("cannot scan %q into %s: %w", text, n.typ, err)
+	}
+	n.value = ptr.Elem().Interface()
+	return nil
+}
+
+// Value implements the driver.Valuer interface for nullSscan.
+func (n *nullSscan) Value() (driver.Value, error) {
+	return n.value, nil
+}
@@ -0,0 +1,42 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type Point struct {
+	X, Y int
+}
+
+func (p *Point) Scan(state fmt.ScanState, verb rune) error {
+	_, err := fmt.Fscanf(state, "(%d,%d)", &p.X, &p.Y)
+	return err
+}
+
+func TestSscanFallback(t *testing.T) {
+	var z *complex128
+	require.Error(t, sqlnull.New(&z).Scan("(1+2i)"))
+
+	config := sqlnull.NewConfig(sqlnull.WithSscanFallback())
+	require.NoError(t, config.New(&z).Scan("(1+2i)"))
+	require.Equal(t, complex(1, 2), *z)
+
+	var p *Point
+	require.NoError(t, config.New(&p).Scan([]byte("(3,4)")))
+	require.Equal(t, Point{X: 3, Y: 4}, *p)
+
+	require.NoError(t, config.New(&p).Scan(nil))
+	require.Nil(t, p)
+
+	require.Error(t, config.New(&p).Scan("lorem"))
+
+	var count *int64
+	require.NoError(t, config.New(&count).Scan(int64(7)))
+	require.Equal(t, int64(7), *count)
+}
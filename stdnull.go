@@ -0,0 +1,17 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import "database/sql"
+
+// isStdNull reports whether target points to one of the sql.Null types of the standard
+// library. They scan themselves, so Target and ScanStruct hand them to database/sql as
+// they are, checked with a type switch rather than the reflection other targets need.
+func isStdNull(target any) bool {
+	switch target.(type) {
+	case *sql.NullBool, *sql.NullByte, *sql.NullInt16, *sql.NullInt32,
+		*sql.NullInt64, *sql.NullFloat64, *sql.NullString, *sql.NullTime:
+		return true
+	}
+	return false
+}
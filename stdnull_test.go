@@ -0,0 +1,36 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type stdNullUser struct {
+	ID         sql.NullInt64
+	FirstName  sql.NullString
+	LastName   sql.NullString
+	VerifiedAt sql.NullTime
+}
+
+func TestStdNullTargets(t *testing.T) {
+	var s sql.NullString
+	require.Same(t, &s, sqlnull.Target(&s))
+
+	db := makeusers(t)
+	rows, err := db.Query("SELECT id, first_name, last_name, verified_at FROM users WHERE id = 2")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	var user stdNullUser
+	require.NoError(t, sqlnull.ScanStruct(rows, &user))
+	require.Equal(t, sql.NullInt64{Int64: 2, Valid: true}, user.ID)
+	require.Equal(t, sql.NullString{String: "jane", Valid: true}, user.FirstName)
+	require.False(t, user.LastName.Valid)
+	require.False(t, user.VerifiedAt.Valid)
+}
@@ -0,0 +1,344 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// structField describes a struct field taking part in struct scanning.
+type structField struct {
+	name    string            // column name, empty for fields that are never scanned
+	index   []int             // index sequence for reflect.Value.FieldByIndex
+	options map[string]string // tag options following the column name
+}
+
+// parseTag splits a `db` struct tag into the column name and its options.
+// Options are written as key or key=value, e.g. `db:"-,derive=FullName"`.
+// The name may be omitted when the tag starts with an option, and an expr
+// option takes the rest of the tag so expressions may contain commas.
+func parseTag(tag string) (string, map[string]string) {
+	parts := strings.Split(tag, ",")
+	name := ""
+	if !strings.Contains(parts[0], "=") {
+		name, parts = strings.TrimSpace(parts[0]), parts[1:]
+	}
+
+	options := make(map[string]string)
+	for i, part := range parts {
+		key, value, _ := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		if key == "expr" {
+			value = strings.Join(append([]string{value}, parts[i+1:]...), ",")
+			options[key] = strings.TrimSpace(value)
+			break
+		}
+		options[key] = strings.TrimSpace(value)
+	}
+	return name, options
+}
+
+// snakeCase converts a Go field name to its snake_case column name, keeping
+// acronyms together, e.g. UserID becomes user_id and HTTPCode becomes http_code.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// fieldCache holds the result of structFields per struct type, so scanning many rows into
+// wide structs does not parse the same tags again for every row.
+var fieldCache sync.Map // map[reflect.Type][]structField
+
+// structFields returns the fields of a struct type that take part in struct scanning.
+// Anonymous struct fields without a tag are flattened into their parent. The result is
+// shared between callers and must not be modified.
+func structFields(t reflect.Type) []structField {
+	if fields, ok := fieldCache.Load(t); ok {
+		return fields.([]structField)
+	}
+	fields, _ := fieldCache.LoadOrStore(t, parseFields(t))
+	return fields.([]structField)
+}
+
+// parseFields implements structFields.
+func parseFields(t reflect.Type) []structField {
+	var fields []structField
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup("db")
+		if field.Anonymous && !hasTag && field.Type.Kind() == reflect.Struct {
+			for _, sub := range structFields(field.Type) {
+				sub.index = append([]int{i}, sub.index...)
+				fields = append(fields, sub)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options := parseTag(tag)
+		if name == "-" {
+			if _, ok := options["derive"]; !ok {
+				continue
+			}
+			name = ""
+		} else if name == "" {
+			name = snakeCase(field.Name)
+		}
+		fields = append(fields, structField{
+			name:    name,
+			index:   []int{i},
+			options: options,
+		})
+	}
+
+	return fields
+}
+
+// hasOption reports whether the field's tag carries the named option.
+func (f structField) hasOption(name string) bool {
+	_, ok := f.options[name]
+	return ok
+}
+
+// match reports whether the field receives the given column. Columns match the
+// tag name exactly, or the field name ignoring case and underscores.
+func (f structField) match(column string) bool {
+	if f.name == "" {
+		return false
+	}
+	if f.name == column {
+		return true
+	}
+	return strings.EqualFold(strings.ReplaceAll(f.name, "_", ""), strings.ReplaceAll(column, "_", ""))
+}
+
+// Columns returns the SELECT column list for the struct pointed to by dest, in field order.
+// Fields tagged with an expr option are rendered as "expr AS name", so the computed column
+// and the field receiving it are declared in one place:
+//
+//	type User struct {
+//		ID    int64
+//		Email *string `db:"email_lower,expr=LOWER(email)"`
+//	}
+//
+//	query := "SELECT " + strings.Join(sqlnull.Columns(&User{}), ", ") + " FROM users"
+func Columns(dest any) []string {
+	t := reflect.TypeOf(dest)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var columns []string
+	for _, field := range structFields(t) {
+		if field.name == "" {
+			continue
+		}
+		if expr, ok := field.options["expr"]; ok {
+			columns = append(columns, expr+" AS "+field.name)
+			continue
+		}
+		columns = append(columns, field.name)
+	}
+	return columns
+}
+
+// fieldTarget returns the scan target for a struct field address. Pointer fields
+// are wrapped with NullValue, plain value fields are set to their zero value on
+// NULL, or fail with ErrNull under version 2 behavior, and anything else is handed
+// to database/sql untouched.
+func (c *Config) fieldTarget(target any) any {
+	if isStdNull(target) {
+		return target
+	}
+	if wrapped := c.Target(target); wrapped != target {
+		return wrapped
+	}
+	val := reflect.ValueOf(target)
+	ptr := reflect.New(val.Type())
+	ptr.Elem().Set(val)
+	if _, _, err := c.validate(ptr.Interface()); err == nil {
+		return scanFunc(func(src any) error {
+			if src == nil && c.changed(CompatV1ZeroValue) {
+				return fmt.Errorf("%w %T", ErrNull, target)
+			}
+			return c.wrapError(c.scan(target, src), src, reflect.TypeOf(target).Elem())
+		})
+	}
+	return target
+}
+
+// FieldTarget returns the scan target ScanStruct uses for the struct field target points
+// to, given the `db` tag of the field, so code scanning fields one by one, such as the
+// scanners generated by sqlnullgen, converts them the same way:
+//
+//	err = row.Scan(sqlnull.FieldTarget(&u.ID, ""), sqlnull.FieldTarget(&u.Perms, "perms,set"))
+//
+// An invalid tag option, such as an unknown parser, fails when the column is scanned.
+func FieldTarget(target any, tag string) any {
+	return Default().FieldTarget(target, tag)
+}
+
+// FieldTarget returns the scan target ScanStruct uses for the struct field target points
+// to, given the `db` tag of the field.
+func (c *Config) FieldTarget(target any, tag string) any {
+	name, options := parseTag(tag)
+	scanner, err := c.fieldScanner(structField{name: name, options: options}, target)
+	if err != nil {
+		return scanFunc(func(any) error { return err })
+	}
+	return scanner
+}
+
+// ScanStruct scans the current row of rows into the struct pointed to by dest,
+// matching columns to fields by `db` tag or field name.
+//
+// Fields tagged `db:"-,derive=Method"` are not scanned; once all columns are
+// assigned, Method is called on dest and its result is stored in the field.
+// Method takes no arguments and returns the field value, optionally followed by an error.
+//
+// Fields tagged `db:"name,parse=func"` are converted by the parse function
+// registered under that name with RegisterParser, fields tagged `db:"name,set"`
+// are filled from a MySQL SET column like SetOf does, fields tagged
+// `db:"name,composite"` are filled from a Postgres composite value like Composite does,
+// fields tagged `db:"name,json"` are decoded from a JSON document like JSON does, and
+// fields tagged `db:"name,rune"` are filled from a single character like Rune does.
+func ScanStruct(rows *sql.Rows, dest any) error {
+	return Default().ScanStruct(rows, dest)
+}
+
+// ScanStruct scans the current row of rows into the struct pointed to by dest,
+// matching columns to fields by `db` tag or field name.
+func (c *Config) ScanStruct(rows *sql.Rows, dest any) error {
+	return c.scanStruct(rows, dest, nil)
+}
+
+// scanStruct implements ScanStruct, filling report when it is not nil.
+func (c *Config) scanStruct(rows *sql.Rows, dest any, report *Report) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	return c.scanStructRow(rows.Scan, columns, dest, report)
+}
+
+// scanStructRow scans the row read by scan, with the given columns, into the struct pointed to by dest.
+func (c *Config) scanStructRow(scan func(dest ...any) error, columns []string, dest any, report *Report) error {
+	val := reflect.ValueOf(dest)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ScanStruct destination must be a non-nil pointer to struct, got %T", dest)
+	}
+
+	var err error
+	c.reset(dest)
+	fields := structFields(val.Elem().Type())
+	targets := make([]any, len(columns))
+	for i, column := range columns {
+		if targets[i], err = c.columnTarget(val.Elem(), fields, i, column, column); err != nil {
+			return err
+		}
+		if targets[i] == nil {
+			return fmt.Errorf("missing destination for column %q in %T", column, dest)
+		}
+	}
+
+	if err := c.scanRow(scan, targets, columns, report); err != nil {
+		return err
+	}
+	return derive(val, fields)
+}
+
+// columnTarget returns the scan target for the column at index i of the result set,
+// filling the field of the struct val matching name, or nil when no field matches.
+func (c *Config) columnTarget(val reflect.Value, fields []structField, i int, column, name string) (any, error) {
+	for _, field := range fields {
+		if !field.match(name) {
+			continue
+		}
+		target := val.FieldByIndex(field.index).Addr().Interface()
+		scanner, err := c.fieldScanner(field, target)
+		if err != nil {
+			return nil, err
+		}
+		return c.traced(i, column, target, scanner), nil
+	}
+	return nil, nil
+}
+
+// fieldScanner returns the scan target for the address of field, honouring its set,
+// composite, json, rune and parse tag options.
+func (c *Config) fieldScanner(field structField, target any) (any, error) {
+	scanner := c.fieldTarget(target)
+	if field.hasOption("set") {
+		scanner = SetOf(target)
+	}
+	if field.hasOption("composite") {
+		scanner = c.Composite(target)
+	}
+	if field.hasOption("json") {
+		scanner = JSON(target)
+	}
+	if field.hasOption("rune") {
+		scanner = Rune(target)
+	}
+	if parser, ok := field.options["parse"]; ok {
+		return parsedTarget(parser, target)
+	}
+	return scanner, nil
+}
+
+// derive fills the fields tagged with a derive option by calling the named method on val.
+func derive(val reflect.Value, fields []structField) error {
+	for _, field := range fields {
+		name, ok := field.options["derive"]
+		if !ok {
+			continue
+		}
+
+		method := val.MethodByName(name)
+		if !method.IsValid() {
+			return fmt.Errorf("derive method %s not found on %s", name, val.Type())
+		}
+		methodType := method.Type()
+		if methodType.NumIn() != 0 || methodType.NumOut() < 1 || methodType.NumOut() > 2 ||
+			(methodType.NumOut() == 2 && methodType.Out(1) != reflect.TypeOf((*error)(nil)).Elem()) {
+			return fmt.Errorf("derive method %s on %s must take no arguments and return a value and an optional error", name, val.Type())
+		}
+
+		out := method.Call(nil)
+		if len(out) == 2 && !out[1].IsNil() {
+			return fmt.Errorf("derive method %s: %w", name, out[1].Interface().(error))
+		}
+
+		dst := val.Elem().FieldByIndex(field.index)
+		switch {
+		case out[0].Type().AssignableTo(dst.Type()):
+			dst.Set(out[0])
+		case out[0].Type().ConvertibleTo(dst.Type()):
+			dst.Set(out[0].Convert(dst.Type()))
+		default:
+			return fmt.Errorf("derive method %s returns %s, not assignable to %s", name, out[0].Type(), dst.Type())
+		}
+	}
+	return nil
+}
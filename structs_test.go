@@ -0,0 +1,196 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type UserView struct {
+	ID         int64
+	FirstName  string  `db:"first_name"`
+	LastName   *string `db:"last_name"`
+	VerifiedAt *time.Time
+	FullName   string `db:"-,derive=DisplayName"`
+	Ignored    string `db:"-"`
+}
+
+func (u *UserView) DisplayName() string {
+	if u.LastName == nil {
+		return u.FirstName
+	}
+	return u.FirstName + " " + *u.LastName
+}
+
+type BrokenView struct {
+	ID    int64
+	Label string `db:"-,derive=MakeLabel"`
+}
+
+func (v *BrokenView) MakeLabel() (string, error) {
+	return "", errors.New("no label")
+}
+
+func makeusers(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`
+		CREATE TABLE [users] (
+			[id] INTEGER NOT NULL,
+			[first_name] TEXT NOT NULL,
+			[last_name] TEXT,
+			[verified_at] DATETIME
+		);
+		INSERT INTO users (id, first_name, last_name, verified_at) VALUES (1, 'john', 'doe', NULL), (2, 'jane', NULL, NULL);
+	`)
+	require.NoError(t, err)
+	return db
+}
+
+func TestScanStruct(t *testing.T) {
+	db := makeusers(t)
+
+	rows, err := db.Query("SELECT id, first_name, last_name, verified_at FROM users ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var users []UserView
+	for rows.Next() {
+		var user UserView
+		require.NoError(t, sqlnull.ScanStruct(rows, &user))
+		users = append(users, user)
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, users, 2)
+	require.Equal(t, "john doe", users[0].FullName)
+	require.Equal(t, "jane", users[1].FullName)
+	require.Nil(t, users[1].LastName)
+	require.Nil(t, users[1].VerifiedAt)
+}
+
+func TestScanStructConcurrent(t *testing.T) {
+	type Names struct {
+		FirstName string  `db:"first_name"`
+		LastName  *string `db:"last_name"`
+	}
+	type User struct {
+		ID int64
+		Names
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		db := makeusers(t)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				rows, err := db.Query("SELECT id, first_name, last_name FROM users ORDER BY id")
+				if !assert.NoError(t, err) {
+					return
+				}
+				var users []User
+				for rows.Next() {
+					var user User
+					assert.NoError(t, sqlnull.ScanStruct(rows, &user))
+					users = append(users, user)
+				}
+				assert.NoError(t, rows.Close())
+				assert.Equal(t, []User{{1, Names{"john", sqlnull.Ptr("doe")}}, {2, Names{FirstName: "jane"}}}, users)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestScanStructErrors(t *testing.T) {
+	db := makeusers(t)
+
+	rows, err := db.Query("SELECT id, first_name AS unknown FROM users")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	var user UserView
+	require.Error(t, sqlnull.ScanStruct(rows, &user))
+	require.Error(t, sqlnull.ScanStruct(rows, user))
+	require.NoError(t, rows.Close())
+
+	rows, err = db.Query("SELECT id FROM users")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	var broken BrokenView
+	require.ErrorContains(t, sqlnull.ScanStruct(rows, &broken), "no label")
+}
+
+type UserEmail struct {
+	ID         int64
+	UserID     int64   `db:"-"`
+	Email      *string `db:"email_lower,expr=LOWER(email)"`
+	Domain     *string `db:"expr=COALESCE(SUBSTR(email, INSTR(email, '@') + 1), 'none')"`
+	VerifiedAt *time.Time
+}
+
+func TestColumnsExpr(t *testing.T) {
+	columns := sqlnull.Columns(&UserEmail{})
+	require.Equal(t, []string{
+		"id",
+		"LOWER(email) AS email_lower",
+		"COALESCE(SUBSTR(email, INSTR(email, '@') + 1), 'none') AS domain",
+		"verified_at",
+	}, columns)
+	require.Nil(t, sqlnull.Columns(42))
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE [emails] ([id] INTEGER, [email] TEXT, [verified_at] DATETIME);
+		INSERT INTO emails (id, email, verified_at) VALUES (1, 'John@Example.COM', NULL);
+	`)
+	require.NoError(t, err)
+
+	rows, err := db.Query("SELECT " + strings.Join(columns, ", ") + " FROM emails")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	var user UserEmail
+	require.NoError(t, sqlnull.ScanStruct(rows, &user))
+	require.Equal(t, int64(1), user.ID)
+	require.Equal(t, "john@example.com", *user.Email)
+	require.Equal(t, "Example.COM", *user.Domain)
+	require.Nil(t, user.VerifiedAt)
+}
+
+func TestFieldTarget(t *testing.T) {
+	db := makeusers(t)
+
+	var id int64
+	var last string
+	var perms []string
+	require.NoError(t, db.QueryRow("SELECT id, last_name, 'read,write' FROM users WHERE id=2").Scan(
+		sqlnull.FieldTarget(&id, ""), sqlnull.FieldTarget(&last, "last_name"), sqlnull.FieldTarget(&perms, "perms,set")))
+	require.Equal(t, int64(2), id)
+	require.Empty(t, last)
+	require.Equal(t, []string{"read", "write"}, perms)
+
+	err := db.QueryRow("SELECT id FROM users WHERE id=1").Scan(sqlnull.FieldTarget(&id, "id,parse=nope"))
+	require.Error(t, err)
+}
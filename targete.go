@@ -0,0 +1,65 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// TargetE is Target reporting misconfigured targets instead of returning them unchanged:
+// a target that is not a pointer, or a pointer to pointer whose element type sqlnull cannot
+// convert into, fails here rather than later inside the driver. Scanners and pointers to
+// plain values are returned unchanged, as database/sql handles them on its own.
+func TargetE(target any) (any, error) {
+	return Default().TargetE(target)
+}
+
+// TargetE is Target reporting misconfigured targets instead of returning them unchanged.
+func (c *Config) TargetE(target any) (any, error) {
+	wrapped, err := c.target(target)
+	if err == nil {
+		return wrapped, nil
+	}
+	if _, ok := target.(sql.Scanner); ok {
+		return target, nil
+	}
+
+	targetType := reflect.TypeOf(target)
+	if targetType.Kind() != reflect.Ptr || reflect.ValueOf(target).IsNil() {
+		return nil, fmt.Errorf("scan target must be a non-nil pointer, got %T", target)
+	}
+	if elem := targetType.Elem(); elem.Kind() == reflect.Ptr && !elem.Implements(scannerType) {
+		return nil, err
+	}
+	return target, nil
+}
+
+// ScannerE is Scanner reporting misconfigured targets like TargetE. All targets are checked
+// and the returned error lists every bad one, so a wrong field type does not surface later
+// as an obscure driver error.
+func ScannerE(targets ...any) ([]any, error) {
+	return Default().ScannerE(targets...)
+}
+
+// ScannerE is Scanner reporting misconfigured targets like TargetE.
+func (c *Config) ScannerE(targets ...any) ([]any, error) {
+	result := make([]any, len(targets))
+	var errs []error
+	for i, target := range targets {
+		wrapped, err := c.TargetE(target)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("target #%d: %w", i, err))
+			continue
+		}
+		result[i] = c.traced(i, "", target, wrapped)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result, nil
+}
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
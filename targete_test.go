@@ -0,0 +1,79 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargetE(t *testing.T) {
+	var phone *string
+	target, err := sqlnull.TargetE(&phone)
+	require.NoError(t, err)
+	require.IsType(t, &sqlnull.NullValue{}, target)
+
+	var blob *[]byte
+	var nullable *sql.NullString
+	for _, dest := range []any{&blob, &nullable} {
+		target, err = sqlnull.TargetE(dest)
+		require.NoError(t, err)
+		require.IsType(t, &sqlnull.NullValue{}, target)
+	}
+
+	var id int64
+	var email sql.NullString
+	for _, dest := range []any{&id, &email} {
+		target, err = sqlnull.TargetE(dest)
+		require.NoError(t, err)
+		require.Same(t, dest, target)
+	}
+
+	target, err = sqlnull.TargetE(nil)
+	require.NoError(t, err)
+	require.IsType(t, new(any), target)
+
+	var unsupported *struct{ A int }
+	_, err = sqlnull.TargetE(&unsupported)
+	require.ErrorContains(t, err, "is not supported")
+
+	_, err = sqlnull.TargetE(id)
+	require.ErrorContains(t, err, "non-nil pointer")
+	_, err = sqlnull.TargetE((*int64)(nil))
+	require.ErrorContains(t, err, "non-nil pointer")
+}
+
+func TestScannerE(t *testing.T) {
+	db := makeusers(t)
+
+	var id int64
+	var lastName *string
+	targets, err := sqlnull.ScannerE(&id, &lastName)
+	require.NoError(t, err)
+	require.NoError(t, db.QueryRow("SELECT id, last_name FROM users WHERE id = 2").Scan(targets...))
+	require.Equal(t, int64(2), id)
+	require.Nil(t, lastName)
+
+	var unsupported *struct{ A int }
+	_, err = sqlnull.ScannerE(&id, id, &lastName, &unsupported)
+	require.ErrorContains(t, err, "target #1: scan target must be a non-nil pointer")
+	require.ErrorContains(t, err, "target #3: NullValue for")
+	require.NotContains(t, err.Error(), "target #0")
+}
+
+func TestScannerEArray(t *testing.T) {
+	db := makeusers(t)
+
+	var ids []int64
+	target, err := sqlnull.TargetE(&ids)
+	require.NoError(t, err)
+	require.Implements(t, (*sql.Scanner)(nil), target)
+
+	targets, err := sqlnull.ScannerE(&ids)
+	require.NoError(t, err)
+	require.NoError(t, db.QueryRow("SELECT '{1,2,3}'").Scan(targets...))
+	require.Equal(t, []int64{1, 2, 3}, ids)
+}
@@ -0,0 +1,43 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// tee scans a column into every one of several targets.
+type tee struct {
+	targets []any
+	config  *Config
+}
+
+// Scan implements the sql.Scanner interface for tee.
+func (t *tee) Scan(src any) error {
+	var errs []error
+	for _, target := range t.targets {
+		if err := t.config.scan(target, src); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Tee returns a scanner that fills all of the targets from a single column,
+// e.g. the typed field and a raw audit string:
+//
+//	var amount *float64
+//	var audit string
+//	err = row.Scan(sqlnull.Tee(&amount, &audit))
+func Tee(targets ...any) sql.Scanner {
+	return Default().Tee(targets...)
+}
+
+// Tee returns a scanner that fills all of the targets from a single column.
+func (c *Config) Tee(targets ...any) sql.Scanner {
+	return &tee{
+		targets: targets,
+		config:  c,
+	}
+}
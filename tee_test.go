@@ -0,0 +1,33 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTee(t *testing.T) {
+	var amount *float64
+	var audit string
+	var raw any
+
+	err := sqlnull.Tee(&amount, &audit, &raw).Scan("12.5")
+	require.NoError(t, err)
+	require.Equal(t, 12.5, *amount)
+	require.Equal(t, "12.5", audit)
+	require.Equal(t, "12.5", raw)
+
+	err = sqlnull.Tee(&amount, &audit, &raw).Scan(nil)
+	require.NoError(t, err)
+	require.Nil(t, amount)
+	require.Equal(t, "", audit)
+	require.Nil(t, raw)
+
+	var num *int64
+	err = sqlnull.Tee(&num, &audit).Scan("lorem ipsum")
+	require.Error(t, err)
+	require.Equal(t, "lorem ipsum", audit)
+}
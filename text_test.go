@@ -0,0 +1,92 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type Level int
+
+func (l *Level) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("invalid level %q", b)
+	}
+	return nil
+}
+
+func TestTextUnmarshaler(t *testing.T) {
+	var level *Level
+	require.NoError(t, sqlnull.New(&level).Scan("high"))
+	require.Equal(t, Level(2), *level)
+
+	require.NoError(t, sqlnull.New(&level).Scan([]byte("low")))
+	require.Equal(t, Level(1), *level)
+
+	require.NoError(t, sqlnull.New(&level).Scan(nil))
+	require.Nil(t, level)
+
+	require.ErrorContains(t, sqlnull.New(&level).Scan("medium"), `invalid level "medium"`)
+
+	db := makeusers(t)
+	rows, err := db.Query("SELECT 'high' AS level")
+	require.NoError(t, err)
+	defer rows.Close()
+	var task struct {
+		Level Level `db:"level"`
+	}
+	require.True(t, rows.Next())
+	require.NoError(t, sqlnull.ScanStruct(rows, &task))
+	require.Equal(t, Level(2), task.Level)
+}
+
+func TestTextUnmarshalerAddr(t *testing.T) {
+	db := makeusers(t)
+
+	var addr *netip.Addr
+	require.NoError(t, db.QueryRow("SELECT '192.168.1.10'").Scan(sqlnull.Target(&addr)))
+	require.Equal(t, netip.MustParseAddr("192.168.1.10"), *addr)
+
+	// TEXT returned as []byte, as MySQL drivers do
+	require.NoError(t, sqlnull.New(&addr).Scan([]byte("::1")))
+	require.Equal(t, netip.IPv6Loopback(), *addr)
+
+	// BLOB holding the binary form
+	require.NoError(t, sqlnull.New(&addr).Scan([]byte{10, 0, 0, 1}))
+	require.Equal(t, netip.MustParseAddr("10.0.0.1"), *addr)
+
+	require.NoError(t, db.QueryRow("SELECT NULL").Scan(sqlnull.Target(&addr)))
+	require.Nil(t, addr)
+
+	require.Error(t, sqlnull.New(&addr).Scan("not an address"))
+}
+
+func TestTextUnmarshalerIntKind(t *testing.T) {
+	var level *Level
+	require.NoError(t, sqlnull.New(&level).Scan(int64(2)))
+	require.Equal(t, Level(2), *level)
+
+	var slogLevel *slog.Level
+	require.NoError(t, sqlnull.New(&slogLevel).Scan(int64(4)))
+	require.Equal(t, slog.LevelWarn, *slogLevel)
+	require.NoError(t, sqlnull.New(&slogLevel).Scan("ERROR"))
+	require.Equal(t, slog.LevelError, *slogLevel)
+
+	var plain slog.Level
+	require.NoError(t, sqlnull.ConvertAssign(&plain, int64(-4)))
+	require.Equal(t, slog.LevelDebug, plain)
+
+	var addr *netip.Addr
+	require.ErrorContains(t, sqlnull.New(&addr).Scan(int64(1)), "cannot unmarshal int64 value into netip.Addr")
+}
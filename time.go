@@ -0,0 +1,117 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WithTimeTruncate truncates scanned times down to a multiple of d, e.g. time.Second
+// or time.Millisecond, so values survive round-trips through databases with a lower
+// timestamp precision and compare equal in tests.
+func WithTimeTruncate(d time.Duration) Option {
+	return func(c *Config) {
+		c.timePrecision, c.timeRound = d, false
+	}
+}
+
+// WithTimeRound rounds scanned times to the nearest multiple of d, like WithTimeTruncate
+// but rounding half up instead of truncating.
+func WithTimeRound(d time.Duration) Option {
+	return func(c *Config) {
+		c.timePrecision, c.timeRound = d, true
+	}
+}
+
+// WithUTC converts every scanned time to UTC before it is assigned, so the zone of
+// a value no longer depends on the driver that returned it.
+func WithUTC() Option {
+	return func(c *Config) {
+		c.utc = true
+	}
+}
+
+// WithTimeLayouts sets the layouts tried in order when a time target receives text, as
+// SQLite and MySQL return for DATETIME columns in formats sql.NullTime cannot parse:
+//
+//	config := sqlnull.NewConfig(sqlnull.WithTimeLayouts("2006-01-02 15:04:05", time.RFC3339))
+//
+// Text matching none of the layouts fails to scan. Layouts without a zone are read as UTC.
+// With layouts set, plain *time.Time targets are wrapped too, NULL setting them to zero.
+func WithTimeLayouts(layouts ...string) Option {
+	return func(c *Config) {
+		c.timeLayouts = slices.Clone(layouts)
+	}
+}
+
+// WithZeroDatesAsNull scans MySQL zero dates, "0000-00-00" or "0000-00-00 00:00:00" with
+// any fractional seconds, and zero time.Time values as NULL, leaving time pointers nil,
+// instead of failing. Plain *time.Time targets are wrapped too, NULL setting them to zero.
+func WithZeroDatesAsNull() Option {
+	return func(c *Config) {
+		c.zeroDatesAsNull = true
+	}
+}
+
+// isZeroDate reports whether src is a MySQL zero date or a zero time.Time.
+func isZeroDate(src any) bool {
+	if t, ok := src.(time.Time); ok {
+		return t.IsZero()
+	}
+	s, ok := text(src)
+	if !ok || !strings.HasPrefix(s, "0000-00-00") {
+		return false
+	}
+	return strings.Trim(s[len("0000-00-00"):], "0:. T") == ""
+}
+
+// parseTime parses text src with the time layouts of c, leaving other values untouched.
+func (c *Config) parseTime(src any) (any, error) {
+	s, ok := text(src)
+	if !ok || len(c.timeLayouts) == 0 {
+		return src, nil
+	}
+	for _, layout := range c.timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot parse %q as time with layouts %s", s, strings.Join(c.timeLayouts, ", "))
+}
+
+// timeTarget returns a scanner for a *time.Time target, which database/sql cannot fill
+// from text, parsing text with the time layouts of c and setting the time to zero on NULL
+// or, with WithZeroDatesAsNull, on a zero date.
+func (c *Config) timeTarget(target *time.Time) sql.Scanner {
+	return scanFunc(func(src any) error {
+		var t *time.Time
+		if err := c.New(&t).Scan(src); err != nil {
+			return err
+		}
+		if t == nil {
+			*target = time.Time{}
+			return nil
+		}
+		*target = *t
+		return nil
+	})
+}
+
+// adjustTime applies the time options of c to t.
+func (c *Config) adjustTime(t time.Time) time.Time {
+	if c.utc {
+		t = t.UTC()
+	}
+	if c.timePrecision > 0 {
+		if c.timeRound {
+			t = t.Round(c.timePrecision)
+		} else {
+			t = t.Truncate(c.timePrecision)
+		}
+	}
+	return t
+}
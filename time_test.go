@@ -0,0 +1,101 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimePrecision(t *testing.T) {
+	src := time.Date(2024, 5, 1, 10, 30, 15, 987654321, time.UTC)
+
+	var at *time.Time
+	require.NoError(t, sqlnull.New(&at).Scan(src))
+	require.Equal(t, src, *at)
+
+	truncate := sqlnull.NewConfig(sqlnull.WithTimeTruncate(time.Second))
+	require.NoError(t, truncate.New(&at).Scan(src))
+	require.Equal(t, time.Date(2024, 5, 1, 10, 30, 15, 0, time.UTC), *at)
+
+	round := sqlnull.NewConfig(sqlnull.WithTimeRound(time.Millisecond))
+	require.NoError(t, round.New(&at).Scan(src))
+	require.Equal(t, time.Date(2024, 5, 1, 10, 30, 15, 988000000, time.UTC), *at)
+
+	require.NoError(t, round.New(&at).Scan(nil))
+	require.Nil(t, at)
+}
+
+func TestUTC(t *testing.T) {
+	zone := time.FixedZone("UTC+7", 7*60*60)
+	src := time.Date(2024, 5, 1, 17, 30, 0, 0, zone)
+
+	var at *time.Time
+	require.NoError(t, sqlnull.New(&at).Scan(src))
+	require.Equal(t, zone, at.Location())
+
+	config := sqlnull.NewConfig(sqlnull.WithUTC())
+	require.NoError(t, config.New(&at).Scan(src))
+	require.Equal(t, time.UTC, at.Location())
+	require.Equal(t, time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC), *at)
+
+	var value time.Time
+	require.NoError(t, config.FirstOf(&value).Scan(src))
+	require.Equal(t, time.UTC, value.Location())
+}
+
+func TestTimeLayouts(t *testing.T) {
+	var at *time.Time
+	require.Error(t, sqlnull.New(&at).Scan("2024-05-01 10:30:15"))
+
+	config := sqlnull.NewConfig(sqlnull.WithTimeLayouts("2006-01-02 15:04:05", time.RFC3339))
+	require.NoError(t, config.New(&at).Scan("2024-05-01 10:30:15"))
+	require.Equal(t, time.Date(2024, 5, 1, 10, 30, 15, 0, time.UTC), *at)
+
+	require.NoError(t, config.New(&at).Scan([]byte("2024-05-01T17:30:15+07:00")))
+	require.True(t, time.Date(2024, 5, 1, 10, 30, 15, 0, time.UTC).Equal(*at))
+
+	require.ErrorContains(t, config.New(&at).Scan("01/05/2024"), `cannot parse "01/05/2024" as time with layouts 2006-01-02 15:04:05, 2006-01-02T15:04:05Z07:00`)
+
+	src := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, config.New(&at).Scan(src))
+	require.Equal(t, src, *at)
+
+	require.NoError(t, config.New(&at).Scan(nil))
+	require.Nil(t, at)
+
+	db := makeusers(t)
+	var created time.Time
+	require.NoError(t, config.Scan(db.QueryRow("SELECT '2024-05-01 10:30:15' AS created").Scan, &created))
+	require.Equal(t, time.Date(2024, 5, 1, 10, 30, 15, 0, time.UTC), created)
+
+	// changing the slice passed in leaves the Config untouched
+	layouts := []string{time.DateTime}
+	config = sqlnull.NewConfig(sqlnull.WithTimeLayouts(layouts...))
+	layouts[0] = time.RFC3339
+	require.NoError(t, config.New(&at).Scan("2024-05-01 10:30:15"))
+}
+
+func TestZeroDatesAsNull(t *testing.T) {
+	var at *time.Time
+	require.Error(t, sqlnull.New(&at).Scan("0000-00-00 00:00:00"))
+
+	config := sqlnull.NewConfig(sqlnull.WithZeroDatesAsNull(), sqlnull.WithTimeLayouts(time.DateTime))
+	for _, src := range []any{"0000-00-00", "0000-00-00 00:00:00", []byte("0000-00-00 00:00:00.000000"), time.Time{}} {
+		at = new(time.Time)
+		require.NoError(t, config.New(&at).Scan(src), "%v", src)
+		require.Nil(t, at)
+	}
+
+	require.NoError(t, config.New(&at).Scan("2024-05-01 10:30:15"))
+	require.Equal(t, time.Date(2024, 5, 1, 10, 30, 15, 0, time.UTC), *at)
+	require.Error(t, config.New(&at).Scan("0000-00-00 00:00:01"))
+
+	db := makeusers(t)
+	created := time.Now()
+	require.NoError(t, sqlnull.NewConfig(sqlnull.WithZeroDatesAsNull()).Scan(db.QueryRow("SELECT '0000-00-00 00:00:00'").Scan, &created))
+	require.True(t, created.IsZero())
+}
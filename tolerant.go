@@ -0,0 +1,47 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"reflect"
+	"strings"
+)
+
+// WithTolerantNumbers makes numeric targets accept messy text values: surrounding
+// whitespace, thousands separators (',' and '_') and a leading '+' are removed
+// before the value is parsed, e.g. " +1,234.50 " scans as 1234.5. Scientific
+// notation such as " 1.5e+10 " is kept intact.
+func WithTolerantNumbers() Option {
+	return func(c *Config) {
+		c.tolerantNumbers = true
+	}
+}
+
+// isNumeric reports whether kind is an integer or floating point kind.
+func isNumeric(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// cleanNumber strips whitespace, thousands separators and a leading '+' from a numeric text value.
+func cleanNumber(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "+")
+	return strings.NewReplacer(",", "", "_", "").Replace(s)
+}
+
+// tolerantNumber cleans string and []byte values with cleanNumber, leaving other values untouched.
+func tolerantNumber(src any) any {
+	switch s := src.(type) {
+	case string:
+		return cleanNumber(s)
+	case []byte:
+		return cleanNumber(string(s))
+	}
+	return src
+}
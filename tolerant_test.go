@@ -0,0 +1,32 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTolerantNumbers(t *testing.T) {
+	var amount *float64
+	require.Error(t, sqlnull.New(&amount).Scan(" +1,234.50 "))
+
+	config := sqlnull.NewConfig(sqlnull.WithTolerantNumbers())
+	require.NoError(t, config.New(&amount).Scan(" +1,234.50 "))
+	require.Equal(t, 1234.5, *amount)
+
+	var count *int32
+	require.NoError(t, config.New(&count).Scan([]byte("\t1_000_000\n")))
+	require.Equal(t, int32(1000000), *count)
+
+	require.NoError(t, config.New(&count).Scan("-42"))
+	require.Equal(t, int32(-42), *count)
+
+	var name *string
+	require.NoError(t, config.New(&name).Scan(" +1,234 "))
+	require.Equal(t, " +1,234 ", *name)
+
+	require.Error(t, config.New(&count).Scan("12 apples"))
+}
@@ -0,0 +1,131 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TraceEntry records how a single column was converted.
+type TraceEntry struct {
+	Index  int    // column position within the scan
+	Column string // column name, when known
+	Source string // Go type of the driver value, "<nil>" for NULL
+	Path   string // conversion used, e.g. "*sql.NullInt64"
+	Value  any    // value held by the target after Scan, nil for NULL
+	Err    error  // conversion error, if any
+}
+
+// Trace collects TraceEntry records from every scan made with a Config built with WithTrace.
+// A Trace is safe for concurrent use.
+type Trace struct {
+	mu      sync.Mutex
+	entries []TraceEntry
+}
+
+// WithTrace records the conversion of every column scanned through Scanner or ScanStruct into trace.
+// It is meant for debugging "why did this column become zero?" questions.
+func WithTrace(trace *Trace) Option {
+	return func(c *Config) {
+		c.trace = trace
+	}
+}
+
+// Entries returns a copy of the recorded entries.
+func (t *Trace) Entries() []TraceEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TraceEntry(nil), t.entries...)
+}
+
+// Reset discards the recorded entries.
+func (t *Trace) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = nil
+}
+
+// String formats the recorded entries one per line.
+func (t *Trace) String() string {
+	var b strings.Builder
+	for _, entry := range t.Entries() {
+		fmt.Fprintf(&b, "#%d %s: %s via %s -> %#v", entry.Index, entry.Column, entry.Source, entry.Path, entry.Value)
+		if entry.Err != nil {
+			fmt.Fprintf(&b, " (error: %v)", entry.Err)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func (t *Trace) add(entry TraceEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, entry)
+}
+
+// traced wraps scanner so its conversion into target is recorded, when tracing or
+// profiling is enabled. Targets left for database/sql to handle are not traced.
+func (c *Config) traced(index int, column string, target any, scanner any) any {
+	s, ok := scanner.(sql.Scanner)
+	if !ok {
+		return scanner
+	}
+	if c.trace != nil {
+		inner := s
+		s = scanFunc(func(src any) error {
+			err := inner.Scan(src)
+			c.trace.add(TraceEntry{
+				Index:  index,
+				Column: column,
+				Source: fmt.Sprintf("%T", src),
+				Path:   c.conversionPath(target, inner),
+				Value:  indirect(target),
+				Err:    err,
+			})
+			return err
+		})
+	}
+	if c.profile != nil {
+		s = c.profiled(index, column, target, s)
+	}
+	return s
+}
+
+// conversionPath describes the conversion used to scan into target.
+func (c *Config) conversionPath(target any, s sql.Scanner) string {
+	switch s.(type) {
+	case *NullValue:
+	case scanFunc:
+		// plain value fields are scanned through a pointer to them
+		val := reflect.ValueOf(target)
+		ptr := reflect.New(val.Type())
+		ptr.Elem().Set(val)
+		target = ptr.Interface()
+	default:
+		return fmt.Sprintf("%T", s)
+	}
+	if null, _, err := c.validate(target); err == nil {
+		return fmt.Sprintf("%T", null)
+	}
+	return fmt.Sprintf("%T", s)
+}
+
+// indirect follows pointers down to the final value, returning nil for a nil pointer.
+func indirect(v any) any {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if !val.IsValid() || !val.CanInterface() {
+		return nil
+	}
+	return val.Interface()
+}
@@ -0,0 +1,71 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrace(t *testing.T) {
+	row, err := makedatabase(true, nil, 88.89, time.Now(), "lorem ipsum")
+	require.NoError(t, err)
+
+	var trace sqlnull.Trace
+	config := sqlnull.NewConfig(sqlnull.WithTrace(&trace))
+
+	var test NewSqlNullTest
+	err = row.Scan(config.Scanner(
+		&test.FieldBool,
+		&test.FieldByte,
+		&test.FieldFloat,
+		&test.FieldInt16,
+		&test.FieldInt32,
+		&test.FieldInt64,
+		&test.FieldString,
+		&test.FieldTime,
+		nil,
+	)...)
+	require.NoError(t, err)
+
+	entries := trace.Entries()
+	require.Len(t, entries, 8)
+	require.Equal(t, 1, entries[1].Index)
+	require.Equal(t, "<nil>", entries[1].Source)
+	require.Equal(t, "*sql.NullByte", entries[1].Path)
+	require.Nil(t, entries[1].Value)
+	require.Equal(t, "*sql.NullFloat64", entries[2].Path)
+	require.Equal(t, float32(88.89), entries[2].Value)
+	require.Equal(t, "lorem ipsum", entries[6].Value)
+	require.Contains(t, trace.String(), "#6 : string via *sql.NullString")
+
+	trace.Reset()
+	require.Empty(t, trace.Entries())
+}
+
+func TestTraceScanStruct(t *testing.T) {
+	db := makeusers(t)
+
+	var trace sqlnull.Trace
+	config := sqlnull.NewConfig(sqlnull.WithTrace(&trace))
+
+	rows, err := db.Query("SELECT id, last_name FROM users WHERE id=2")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	var user UserView
+	require.NoError(t, config.ScanStruct(rows, &user))
+
+	entries := trace.Entries()
+	require.Len(t, entries, 2)
+	require.Equal(t, "id", entries[0].Column)
+	require.Equal(t, int64(2), entries[0].Value)
+	require.Equal(t, "*sql.NullInt64", entries[0].Path)
+	require.Equal(t, "last_name", entries[1].Column)
+	require.Equal(t, "<nil>", entries[1].Source)
+	require.Nil(t, entries[1].Value)
+}
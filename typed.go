@@ -0,0 +1,57 @@
+package sqlnull
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// TargetOf returns a scanner for a nullable column without reflection: NULL sets *dst to
+// nil, any other value is converted into a new T like database/sql converts scan targets.
+// Unlike Target it ignores the Config, and it is available in the sqlnull_lite build.
+//
+//	var phone *string
+//	err = row.Scan(sqlnull.TargetOf(&phone))
+func TargetOf[T any](dst **T) sql.Scanner {
+	return scanFunc(func(src any) error {
+		var n sql.Null[T]
+		if err := n.Scan(src); err != nil {
+			return err
+		}
+		if !n.Valid {
+			*dst = nil
+			return nil
+		}
+		*dst = &n.V
+		return nil
+	})
+}
+
+// ValueOf returns a scanner for a plain value without reflection: NULL sets *dst to the
+// zero value of T.
+func ValueOf[T any](dst *T) sql.Scanner {
+	return scanFunc(func(src any) error {
+		var n sql.Null[T]
+		if err := n.Scan(src); err != nil {
+			return err
+		}
+		*dst = n.V
+		return nil
+	})
+}
+
+// ArgOf returns a statement argument sending v as NULL when it is nil and *v otherwise,
+// without reflection.
+func ArgOf[T any](v *T) driver.Valuer {
+	if v == nil {
+		return sql.Null[T]{}
+	}
+	return sql.Null[T]{V: *v, Valid: true}
+}
+
+// scanFunc adapts a function to the sql.Scanner interface.
+type scanFunc func(src any) error
+
+// Scan implements the sql.Scanner interface for scanFunc.
+func (f scanFunc) Scan(src any) error {
+	return f(src)
+}
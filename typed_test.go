@@ -0,0 +1,38 @@
+package sqlnull_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedTargets(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	var id int64
+	var name *string
+	var age int
+	require.NoError(t, db.QueryRow("SELECT 7, 'john', NULL").Scan(sqlnull.ValueOf(&id), sqlnull.TargetOf(&name), sqlnull.ValueOf(&age)))
+	require.Equal(t, int64(7), id)
+	require.Equal(t, "john", *name)
+	require.Zero(t, age)
+
+	age = 5
+	require.NoError(t, db.QueryRow("SELECT NULL, NULL").Scan(sqlnull.TargetOf(&name), sqlnull.ValueOf(&age)))
+	require.Nil(t, name)
+	require.Zero(t, age)
+
+	var got *string
+	require.NoError(t, db.QueryRow("SELECT ?", sqlnull.ArgOf[string](nil)).Scan(sqlnull.TargetOf(&got)))
+	require.Nil(t, got)
+	phone := "123"
+	require.NoError(t, db.QueryRow("SELECT ?", sqlnull.ArgOf(&phone)).Scan(sqlnull.TargetOf(&got)))
+	require.Equal(t, "123", *got)
+
+	require.Error(t, db.QueryRow("SELECT 'x'").Scan(sqlnull.ValueOf(&id)))
+}
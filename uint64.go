@@ -0,0 +1,73 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// nullUint64 scans uint64 and uint targets over their full range. Values above
+// math.MaxInt64 arrive as uint64 from some drivers and as decimal text from others.
+type nullUint64 struct {
+	value uint64
+	valid bool
+}
+
+// Scan implements the sql.Scanner interface for nullUint64.
+func (n *nullUint64) Scan(src any) error {
+	n.value, n.valid = 0, false
+
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("value %d out of range for uint64", v)
+		}
+		n.value = uint64(v)
+	case uint64:
+		n.value = v
+	case float64:
+		if v < 0 || v >= math.MaxUint64 || v != math.Trunc(v) {
+			return fmt.Errorf("value %v out of range for uint64", v)
+		}
+		n.value = uint64(v)
+	case string:
+		return n.parse(v)
+	case []byte:
+		return n.parse(string(v))
+	case bool:
+		if v {
+			n.value = 1
+		}
+	default:
+		return fmt.Errorf("cannot scan %T value into uint64", src)
+	}
+	n.valid = true
+	return nil
+}
+
+// parse reads a decimal text value.
+func (n *nullUint64) parse(s string) error {
+	u, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return fmt.Errorf("value %s out of range for uint64", s)
+		}
+		return fmt.Errorf("converting %q to uint64: %w", s, err)
+	}
+	n.value, n.valid = u, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface for nullUint64.
+func (n *nullUint64) Value() (driver.Value, error) {
+	if !n.valid {
+		return nil, nil
+	}
+	return n.value, nil
+}
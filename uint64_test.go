@@ -0,0 +1,45 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUint64(t *testing.T) {
+	var id *uint64
+	require.NoError(t, sqlnull.New(&id).Scan("18446744073709551615"))
+	require.Equal(t, uint64(math.MaxUint64), *id)
+
+	require.NoError(t, sqlnull.New(&id).Scan(uint64(math.MaxInt64)+1))
+	require.Equal(t, uint64(math.MaxInt64)+1, *id)
+
+	require.NoError(t, sqlnull.New(&id).Scan(int64(42)))
+	require.Equal(t, uint64(42), *id)
+
+	require.NoError(t, sqlnull.New(&id).Scan(nil))
+	require.Nil(t, id)
+
+	require.ErrorContains(t, sqlnull.New(&id).Scan("18446744073709551616"), "out of range")
+	require.ErrorContains(t, sqlnull.New(&id).Scan(int64(-1)), "out of range")
+	require.Error(t, sqlnull.New(&id).Scan("lorem"))
+
+	var u *uint
+	require.NoError(t, sqlnull.New(&u).Scan([]byte("9223372036854775808")))
+	require.Equal(t, uint(math.MaxInt64)+1, *u)
+}
+
+func TestUint64Sqlite(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	var id *uint64
+	require.NoError(t, db.QueryRow("SELECT '18446744073709551615'").Scan(sqlnull.Target(&id)))
+	require.Equal(t, uint64(math.MaxUint64), *id)
+}
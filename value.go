@@ -0,0 +1,199 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// WithArgTimeLayout renders time.Time statement arguments as text formatted with layout,
+// e.g. time.RFC3339 or "2006-01-02 15:04:05.000", instead of handing them to the driver
+// as they are. SQLite stores datetimes as TEXT, so a consistent layout keeps them sorting
+// correctly. An empty layout restores the driver-native behavior.
+func WithArgTimeLayout(layout string) Option {
+	return func(c *Config) {
+		c.argTimeLayout = layout
+	}
+}
+
+// WithArgTimePrecision truncates time.Time statement arguments to a multiple of d,
+// e.g. time.Millisecond, before they are rendered.
+func WithArgTimePrecision(d time.Duration) Option {
+	return func(c *Config) {
+		c.argTimePrecision = d
+	}
+}
+
+// WithZeroTimeAsNull sends zero time.Time statement arguments as NULL, matching the
+// common convention where unset timestamps are stored as NULL.
+func WithZeroTimeAsNull() Option {
+	return func(c *Config) {
+		c.zeroTimeAsNull = true
+	}
+}
+
+// WithZeroAsNull sends zero values of the given kinds as NULL, so value-typed fields,
+// e.g. an empty string with reflect.String or 0 with reflect.Int64, still produce NULLs
+// without switching to pointers. It applies to plain values, not to non-nil pointers.
+// A single field can opt in with the zeronull tag option: `db:"phone,zeronull"`.
+func WithZeroAsNull(kinds ...reflect.Kind) Option {
+	return func(c *Config) {
+		if c.zeroAsNull == nil {
+			c.zeroAsNull = make(map[reflect.Kind]bool)
+		}
+		for _, kind := range kinds {
+			c.zeroAsNull[kind] = true
+		}
+	}
+}
+
+// ZeroAsNull returns a statement argument sending *v as NULL when v is nil or points to the zero value:
+//
+//	_, err = db.Exec("UPDATE users SET verified_at=? WHERE id=?", sqlnull.ZeroAsNull(&user.VerifiedAt), user.ID)
+func ZeroAsNull[T any](v *T) driver.Valuer {
+	return zeroValue[T]{v: v}
+}
+
+// zeroValue implements ZeroAsNull.
+type zeroValue[T any] struct {
+	v *T
+}
+
+// Value implements the driver.Valuer interface for zeroValue.
+func (z zeroValue[T]) Value() (driver.Value, error) {
+	if z.v == nil || reflect.ValueOf(z.v).Elem().IsZero() {
+		return nil, nil
+	}
+	v, err := Default().driverValue(*z.v)
+	if err != nil {
+		return nil, err
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+// Args converts values into statement arguments, so struct fields can be passed straight
+// to Exec: pointers are dereferenced, nil pointers become NULL, named types such as
+// `type Status string` become their driver type, and times follow the write options.
+// Values implementing driver.Valuer, including those returned by Redact and ZeroAsNull,
+// are left for database/sql to call. A value that fails to convert is replaced by an
+// argument returning the error, so it surfaces from Exec.
+//
+//	_, err = db.Exec("UPDATE users SET phone=?, status=? WHERE id=?", sqlnull.Args(user.Phone, user.Status, user.ID)...)
+func Args(values ...any) []any {
+	return Default().Args(values...)
+}
+
+// Args converts values into statement arguments with the write options of c.
+func (c *Config) Args(values ...any) []any {
+	args := make([]any, len(values))
+	for i, v := range values {
+		arg, err := c.driverValue(v)
+		if _, ok := arg.(driver.Valuer); !ok && err == nil {
+			arg, err = driver.DefaultParameterConverter.ConvertValue(arg)
+		}
+		if err != nil {
+			arg = failedArg{err: fmt.Errorf("argument %d: %w", i+1, err)}
+		}
+		args[i] = arg
+	}
+	return args
+}
+
+// failedArg is a statement argument returning the error met while converting it.
+type failedArg struct {
+	err error
+}
+
+// Value implements the driver.Valuer interface for failedArg.
+func (a failedArg) Value() (driver.Value, error) {
+	return nil, a.err
+}
+
+// Value converts v into a driver.Value like Args does, calling driver.Valuer implementations.
+func Value(v any) (driver.Value, error) {
+	return Default().Value(v)
+}
+
+// Value converts v into a driver.Value with the write options of c.
+func (c *Config) Value(v any) (driver.Value, error) {
+	v, err := c.driverValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+// driverValue prepares a statement argument: nil pointers and empty Option types such
+// as mo.Option[T] become NULL, types registered with RegisterValuer are converted by their
+// function, types registered with RegisterSet are rendered as SET text, times are rendered
+// with the write options of c, and types implementing encoding.BinaryMarshaler but not
+// driver.Valuer are marshaled. Anything else is returned unchanged for database/sql to
+// convert.
+func (c *Config) driverValue(v any) (any, error) {
+	val := reflect.ValueOf(v)
+	if val.IsValid() && c.zeroAsNull[val.Kind()] && val.IsZero() {
+		return nil, nil
+	}
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil, nil
+		}
+		if val.Type().Implements(valuerType) && !isOptionType(val.Type().Elem()) {
+			return v, nil
+		}
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return v, nil
+	}
+	if fn, ok := lookupValuer(val.Type()); ok {
+		return fn(val.Interface())
+	}
+	if isOptionType(val.Type()) {
+		value, ok := optionValue(val)
+		if !ok {
+			return nil, nil
+		}
+		return c.driverValue(value)
+	}
+	if _, ok := lookupMembers(val.Type()); ok && isUnsigned(val.Kind()) {
+		return setText(val)
+	}
+	if isByteArray(val.Type()) && !val.Type().Implements(valuerType) {
+		b := make([]byte, val.Len())
+		reflect.Copy(reflect.ValueOf(b), val)
+		return b, nil
+	}
+	if val.Type() == timeType {
+		return c.timeArg(val.Interface().(time.Time)), nil
+	}
+	if val.Type().Implements(valuerType) {
+		return v, nil
+	}
+
+	if val.Type().Implements(binaryMarshalerType) {
+		return val.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+	}
+	if val.CanAddr() && reflect.PointerTo(val.Type()).Implements(binaryMarshalerType) {
+		return val.Addr().Interface().(encoding.BinaryMarshaler).MarshalBinary()
+	}
+	return v, nil
+}
+
+// timeArg renders t as a statement argument.
+func (c *Config) timeArg(t time.Time) any {
+	if c.zeroTimeAsNull && t.IsZero() {
+		return nil
+	}
+	if c.argTimePrecision > 0 {
+		t = t.Truncate(c.argTimePrecision)
+	}
+	if c.argTimeLayout != "" {
+		return t.Format(c.argTimeLayout)
+	}
+	return t
+}
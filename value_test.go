@@ -0,0 +1,146 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArgTimeFormat(t *testing.T) {
+	type Event struct {
+		ID       int64
+		At       time.Time
+		Optional *time.Time
+	}
+
+	at := time.Date(2024, 5, 1, 10, 30, 15, 987654321, time.UTC)
+	_, args, err := sqlnull.Insert("events", &Event{ID: 1, At: at, Optional: &at})
+	require.NoError(t, err)
+	require.Equal(t, at, args[1])
+	require.Equal(t, at, args[2])
+
+	config := sqlnull.NewConfig(sqlnull.WithArgTimeLayout(time.RFC3339Nano), sqlnull.WithArgTimePrecision(time.Millisecond))
+	_, args, err = config.Insert("events", &Event{ID: 1, At: at})
+	require.NoError(t, err)
+	require.Equal(t, "2024-05-01T10:30:15.987Z", args[1])
+	require.Nil(t, args[2])
+
+	config = sqlnull.NewConfig(sqlnull.WithArgTimeLayout("2006-01-02 15:04:05"))
+	_, args, err = config.Insert("events", &Event{ID: 1, At: at, Optional: &at})
+	require.NoError(t, err)
+	require.Equal(t, "2024-05-01 10:30:15", args[2])
+}
+
+func TestZeroTimeAsNull(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE [events] ([id] INTEGER, [at] DATETIME)`)
+	require.NoError(t, err)
+
+	var zero time.Time
+	now := time.Now()
+	_, err = db.Exec("INSERT INTO events (id, at) VALUES (1, ?), (2, ?), (3, ?)", sqlnull.ZeroAsNull(&zero), sqlnull.ZeroAsNull[time.Time](nil), sqlnull.ZeroAsNull(&now))
+	require.NoError(t, err)
+
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM events WHERE at IS NULL").Scan(&count))
+	require.Equal(t, 2, count)
+
+	type Event struct {
+		ID int64
+		At time.Time
+	}
+	_, args, err := sqlnull.Insert("events", &Event{ID: 4})
+	require.NoError(t, err)
+	require.Equal(t, zero, args[1])
+
+	_, args, err = sqlnull.NewConfig(sqlnull.WithZeroTimeAsNull()).Insert("events", &Event{ID: 4})
+	require.NoError(t, err)
+	require.Nil(t, args[1])
+}
+
+type CustomCode string
+
+func TestZeroAsNullPolicy(t *testing.T) {
+	type Contact struct {
+		ID    int64
+		Phone string `db:"phone,zeronull"`
+		Email string
+		Score int32
+		Code  *CustomCode
+	}
+
+	empty := CustomCode("")
+	_, args, err := sqlnull.Insert("contacts", &Contact{ID: 1, Code: &empty})
+	require.NoError(t, err)
+	require.Equal(t, []any{int64(1), nil, "", int32(0), &empty}, args)
+
+	config := sqlnull.NewConfig(sqlnull.WithZeroAsNull(reflect.String, reflect.Int32))
+	_, args, err = config.Insert("contacts", &Contact{ID: 1, Code: &empty})
+	require.NoError(t, err)
+	require.Equal(t, []any{int64(1), nil, nil, nil, &empty}, args)
+
+	_, args, err = config.Insert("contacts", &Contact{ID: 1, Phone: "123", Email: "a@b.c", Score: 5})
+	require.NoError(t, err)
+	require.Equal(t, []any{int64(1), "123", "a@b.c", int32(5), nil}, args)
+
+	code := CustomCode("x")
+	value, err := sqlnull.ZeroAsNull(&code).Value()
+	require.NoError(t, err)
+	require.Equal(t, "x", value)
+	value, err = sqlnull.ZeroAsNull(&empty).Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+	count := 0
+	value, err = sqlnull.ZeroAsNull(&count).Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+type argStatus string
+
+type argCount int64
+
+func TestArgs(t *testing.T) {
+	phone := "123"
+	var missing *string
+	count := argCount(3)
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	args := sqlnull.Args(&phone, missing, argStatus("active"), &count, when, nil)
+	require.Equal(t, []any{"123", nil, "active", int64(3), when, nil}, args)
+
+	config := sqlnull.NewConfig(sqlnull.WithArgTimeLayout(time.DateOnly), sqlnull.WithZeroTimeAsNull())
+	require.Equal(t, []any{"2024-01-02", nil}, config.Args(when, time.Time{}))
+
+	redacted := sqlnull.Redact("secret")
+	require.Equal(t, []any{redacted}, sqlnull.Args(redacted))
+
+	args = sqlnull.Args(struct{}{})
+	_, err := args[0].(driver.Valuer).Value()
+	require.ErrorContains(t, err, "argument 1")
+
+	v, err := sqlnull.Value(&count)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), v)
+	v, err = sqlnull.Value(redacted)
+	require.NoError(t, err)
+	require.Equal(t, "secret", v)
+	v, err = sqlnull.Value(missing)
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	db := makeusers(t)
+	_, err = db.Exec("UPDATE users SET last_name=? WHERE id=?", sqlnull.Args(missing, &count)...)
+	require.NoError(t, err)
+}
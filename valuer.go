@@ -0,0 +1,39 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"sync"
+)
+
+// ValuerFunc converts a value of a registered type into a statement argument.
+type ValuerFunc func(v any) (driver.Value, error)
+
+var (
+	valuersMu sync.RWMutex
+	valuers   = map[reflect.Type]ValuerFunc{}
+)
+
+// RegisterValuer registers fn as the write-side conversion of values of type t, so domain
+// types from packages you do not own, which lack a driver.Valuer implementation, can be
+// used with Insert, CopyFrom, NewBulkWriter and the other argument helpers. fn receives
+// a t, never a pointer to it: nil pointers to t are sent as NULL without calling fn.
+//
+//	sqlnull.RegisterValuer(reflect.TypeOf(money.Amount{}), func(v any) (driver.Value, error) {
+//		return v.(money.Amount).String(), nil
+//	})
+func RegisterValuer(t reflect.Type, fn ValuerFunc) {
+	valuersMu.Lock()
+	defer valuersMu.Unlock()
+	valuers[t] = fn
+}
+
+// lookupValuer returns the ValuerFunc registered for t.
+func lookupValuer(t reflect.Type) (ValuerFunc, bool) {
+	valuersMu.RLock()
+	defer valuersMu.RUnlock()
+	fn, ok := valuers[t]
+	return fn, ok
+}
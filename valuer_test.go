@@ -0,0 +1,46 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+type valuerPoint struct {
+	X, Y int
+}
+
+func init() {
+	sqlnull.RegisterValuer(reflect.TypeOf(valuerPoint{}), func(v any) (driver.Value, error) {
+		p := v.(valuerPoint)
+		return fmt.Sprintf("(%d,%d)", p.X, p.Y), nil
+	})
+}
+
+func TestRegisterValuer(t *testing.T) {
+	type Place struct {
+		Name   string
+		Center valuerPoint
+		Corner *valuerPoint
+	}
+
+	query, args, err := sqlnull.Insert("places", &Place{Name: "home", Center: valuerPoint{1, 2}})
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO places (name, center, corner) VALUES (?, ?, ?)", query)
+	require.Equal(t, []any{"home", "(1,2)", nil}, args)
+
+	_, args, err = sqlnull.Insert("places", &Place{Corner: &valuerPoint{3, 4}})
+	require.NoError(t, err)
+	require.Equal(t, "(3,4)", args[2])
+
+	db := makeusers(t)
+	var s string
+	require.NoError(t, db.QueryRow("SELECT ?", sqlnull.ZeroAsNull(&valuerPoint{5, 6})).Scan(&s))
+	require.Equal(t, "(5,6)", s)
+}
@@ -0,0 +1,57 @@
+//go:build !sqlnull_lite
+
+package sqlnull
+
+import (
+	"fmt"
+	"io"
+)
+
+// StreamTarget is a scan destination that writes BLOB or TEXT column bytes to an io.Writer.
+type StreamTarget struct {
+	w       io.Writer
+	null    bool
+	written int64
+}
+
+// WriteTo returns a destination that streams the column bytes into w during Scan,
+// e.g. a file, a hash or an upload, instead of materializing a []byte. The bytes are
+// written straight from the driver's buffer. NULL writes nothing and sets IsNull.
+//
+//	h := sha256.New()
+//	blob := sqlnull.WriteTo(h)
+//	err = row.Scan(&id, blob)
+func WriteTo(w io.Writer) *StreamTarget {
+	return &StreamTarget{w: w}
+}
+
+// Scan implements the sql.Scanner interface for StreamTarget.
+func (t *StreamTarget) Scan(src any) error {
+	t.null, t.written = false, 0
+
+	var n int
+	var err error
+	switch v := src.(type) {
+	case nil:
+		t.null = true
+		return nil
+	case []byte:
+		n, err = t.w.Write(v)
+	case string:
+		n, err = io.WriteString(t.w, v)
+	default:
+		return fmt.Errorf("cannot stream %T value into io.Writer", src)
+	}
+	t.written = int64(n)
+	return err
+}
+
+// IsNull reports whether the last scanned value was NULL.
+func (t *StreamTarget) IsNull() bool {
+	return t.null
+}
+
+// Written returns the number of bytes written by the last Scan.
+func (t *StreamTarget) Written() int64 {
+	return t.written
+}
@@ -0,0 +1,40 @@
+//go:build !sqlnull_lite
+
+package sqlnull_test
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+
+	"github.com/ceebydith/sqlnull"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTo(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE [files] ([id] INTEGER, [content] BLOB);
+		INSERT INTO files (id, content) VALUES (1, x'00010203'), (2, NULL);
+	`)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	var id int64
+	blob := sqlnull.WriteTo(&buf)
+
+	require.NoError(t, db.QueryRow("SELECT id, content FROM files WHERE id=1").Scan(&id, blob))
+	require.Equal(t, []byte{0, 1, 2, 3}, buf.Bytes())
+	require.Equal(t, int64(4), blob.Written())
+	require.False(t, blob.IsNull())
+
+	buf.Reset()
+	require.NoError(t, db.QueryRow("SELECT id, content FROM files WHERE id=2").Scan(&id, blob))
+	require.Zero(t, buf.Len())
+	require.True(t, blob.IsNull())
+
+	require.Error(t, blob.Scan(int64(1)))
+}